@@ -2,4 +2,7 @@ module github.com/igork0006/go-magistr-lesson2-tpl
 
 go 1.22
 
-require gopkg.in/yaml.v3 v3.0.1 // indirect
+require (
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ---------- Входные файлы ----------
+
+const stdinName = "<stdin>"
+
+// readInput читает файл по пути или stdin, если путь равен "-".
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+func stdinIsPipe() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice == 0
+}
+
+// readFileList читает список путей из файла (или stdin для "-"): по
+// одному на строку, пустые строки и строки с "#" в начале пропускаются.
+func readFileList(path string) ([]string, error) {
+	content, err := readInput(path)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	return paths, nil
+}
+
+// expandPaths раскрывает шаблоны и каталоги в список файлов манифестов.
+// С recursive обходятся и подкаталоги. Прочие аргументы возвращаются
+// как есть: ошибки чтения будут показаны при проверке. Шаблоны и
+// каталоги, под которые не попал ни один файл, возвращаются в unmatched. Файл,
+// попавший под несколько аргументов, проверяется один раз.
+func expandPaths(args []string, recursive bool) (paths, unmatched []string, err error) {
+	seen := map[string]bool{}
+	for _, arg := range args {
+		matches := []string{arg}
+		if _, statErr := os.Stat(arg); statErr != nil && isGlob(arg) {
+			if matches, err = expandGlob(arg); err != nil {
+				return nil, nil, err
+			}
+			// Из файлов под шаблоном берём только манифесты, как при обходе каталога
+			matches = slices.DeleteFunc(matches, func(m string) bool {
+				info, err := os.Stat(m)
+				return err == nil && !info.IsDir() && !isManifestFile(m)
+			})
+			if len(matches) == 0 {
+				unmatched = append(unmatched, arg)
+				continue
+			}
+		}
+
+		found := 0
+		for _, match := range matches {
+			files, err := expandDir(match, recursive)
+			if err != nil {
+				return nil, nil, err
+			}
+			found += len(files)
+			for _, file := range files {
+				if !seen[file] {
+					seen[file] = true
+					paths = append(paths, file)
+				}
+			}
+		}
+		// Каталог без манифестов — скорее всего опечатка в пути
+		if found == 0 {
+			unmatched = append(unmatched, arg)
+		}
+	}
+	return paths, unmatched, nil
+}
+
+// expandDir возвращает YAML-файлы каталога или сам path, если это не каталог.
+func expandDir(path string, recursive bool) ([]string, error) {
+	info, err := os.Stat(path)
+	if path == "-" || err != nil || !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isManifestFile(p) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// normalizePath приводит путь к виду, выбранному --path: relative —
+// относительно рабочего каталога, absolute — абсолютный. Если путь
+// привести не удалось, он возвращается как есть.
+func normalizePath(path, mode string) string {
+	if path == "-" {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if mode == "absolute" {
+		return abs
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil {
+		return path
+	}
+	return rel
+}
+
+func isManifestFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml" || ext == ".json"
+}
+
+// ---------- Шаблоны ----------
+
+func isGlob(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// expandGlob раскрывает шаблон. filepath.Glob не понимает "**", поэтому
+// такие шаблоны сопоставляются по сегментам при обходе дерева.
+func expandGlob(pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		return filepath.Glob(pattern)
+	}
+
+	// Корень обхода — сегменты до первого со спецсимволами
+	segments := strings.Split(filepath.ToSlash(pattern), "/")
+	n := 0
+	for n < len(segments) && !isGlob(segments[n]) {
+		n++
+	}
+	root := strings.Join(segments[:n], "/")
+	if root == "" && n > 0 {
+		root = "/"
+	} else if root == "" {
+		root = "."
+	}
+
+	var matches []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if p == root && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipAll
+			}
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		if matchSegments(segments[n:], strings.Split(filepath.ToSlash(rel), "/")) {
+			matches = append(matches, p)
+		}
+		return nil
+	})
+	return matches, err
+}
+
+// matchSegments сопоставляет путь с шаблоном посегментно; "**"
+// соответствует любому числу сегментов, в том числе нулю.
+func matchSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	ok, err := filepath.Match(pattern[0], path[0])
+	return err == nil && ok && matchSegments(pattern[1:], path[1:])
+}
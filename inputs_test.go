@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatchSegments(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"*.yaml", "a.yaml", true},
+		{"*.yaml", "a.yml", false},
+		{"*", "sub/a.yaml", false},
+		{"*/*.yaml", "sub/a.yaml", true},
+		{"**", "a.yaml", true},
+		{"**", "sub/deep/a.yaml", true},
+		{"**/*.yaml", "a.yaml", true},
+		{"**/*.yaml", "sub/deep/a.yaml", true},
+		{"**/*.yaml", "sub/deep/a.json", false},
+		{"sub/**/a.yaml", "sub/a.yaml", true},
+		{"sub/**/a.yaml", "other/a.yaml", false},
+		{"**/deep/*", "sub/deep/a.yaml", true},
+	}
+	for _, tt := range tests {
+		got := matchSegments(strings.Split(tt.pattern, "/"), strings.Split(tt.path, "/"))
+		if got != tt.want {
+			t.Errorf("matchSegments(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestExpandPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.yaml", "readme.txt", "sub/b.yml", "docs/notes.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a, b := filepath.Join(dir, "a.yaml"), filepath.Join(dir, "sub", "b.yml")
+
+	docs := filepath.Join(dir, "docs")
+
+	tests := []struct {
+		args      []string
+		want      []string
+		unmatched []string
+	}{
+		// "**" находит и sub, и sub/b.yml; файл проверяется один раз
+		{[]string{filepath.Join(dir, "**")}, []string{a, b}, nil},
+		// readme.txt — не манифест
+		{[]string{filepath.Join(dir, "*")}, []string{a, b}, nil},
+		{[]string{dir, a}, []string{a}, nil},
+		{[]string{filepath.Join(dir, "*.txt")}, nil, []string{filepath.Join(dir, "*.txt")}},
+		// Каталог без манифестов
+		{[]string{docs, a}, []string{a}, []string{docs}},
+	}
+	for _, tt := range tests {
+		got, unmatched, err := expandPaths(tt.args, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(unmatched, tt.unmatched) {
+			t.Errorf("expandPaths(%q) = %q, %q; want %q, %q", tt.args, got, unmatched, tt.want, tt.unmatched)
+		}
+	}
+}
@@ -1,303 +1,473 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"regexp"
+	"runtime"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"text/tabwriter"
 
-	"gopkg.in/yaml.v3"
+	"github.com/igork0006/go-magistr-lesson2-tpl/validator"
+)
+
+// Коды завершения.
+const (
+	exitOK      = 0 // ошибок нет
+	exitInvalid = 1 // манифест нарушает правила
+	exitBroken  = 2 // ошибка использования, чтения или разбора YAML
+)
+
+// Правила для ошибок, возникающих до валидации манифеста.
+const (
+	ruleReadError  = "read-error"
+	ruleParseError = "parse-error"
+	ruleNoMatch    = "no-match"
+)
+
+// Сведения о сборке, задаются через
+// -ldflags "-X main.version=... -X main.commit=... -X main.date=...".
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
 )
 
 func main() {
-	if len(os.Args) != 2 {
-		fmt.Fprintln(os.Stderr, "Usage: yamlvalid <path_to_yaml>")
-		os.Exit(1)
+	listRules := flag.Bool("list-rules", false, "print all rules and exit")
+	explain := flag.String("explain", "", "print the description of a rule and exit")
+	showVersion := flag.Bool("version", false, "print version information and exit")
+	input := flag.String("input", "auto", "input format: auto (by file extension), yaml or json")
+	format := flag.String("format", "text", "output format: text, json or sarif")
+	var quiet bool
+	flag.BoolVar(&quiet, "quiet", false, "print nothing, only set the exit code")
+	flag.BoolVar(&quiet, "q", false, "shorthand for --quiet")
+	colorMode := flag.String("color", "auto", "colorize text output: auto, always or never")
+	filesFrom := flag.String("files-from", "", "read paths to validate from a file, one per line ('-' for stdin)")
+	pathMode := flag.String("path", "relative", "file names in findings: relative (to the working directory) or absolute")
+	recursive := flag.Bool("recursive", false, "descend into subdirectories of directory arguments")
+	maxErrors := flag.Int("max-errors", 0, "print at most N findings (0 - no limit)")
+	jobs := flag.Int("jobs", runtime.NumCPU(), "number of files validated in parallel")
+	bitmaskExit := flag.Bool("bitmask-exit", false, "encode the result in exit code bits: 1 - errors, 2 - warnings, 4 - read or parse errors")
+	failFast := flag.Bool("fail-fast", false, "stop at the first error")
+	strict := flag.Bool("strict", false, "report unknown fields and treat warnings as errors")
+	disallowLatest := flag.Bool("disallow-latest", false, "reject images tagged 'latest'")
+	requireLimits := flag.Bool("require-limits", false, "require cpu and memory requests and limits in every container")
+	warnMissingProbes := flag.Bool("warn-missing-probes", false, "warn about containers without readinessProbe and livenessProbe")
+	kinds := flag.String("kinds", "", "comma-separated list of accepted kinds (default all supported: "+strings.Join(validator.SupportedKinds(), ",")+")")
+	configPath := flag.String("config", "", "load validation rules from a YAML file")
+	schemaPath := flag.String("schema", "", "also validate documents against a JSON Schema (Draft 7) file")
+	schemaOnly := flag.Bool("schema-only", false, "skip built-in rules and validate only against --schema")
+	containerName := flag.String("container-name-pattern", "", "regular expression for container names (default DNS-1123 label)")
+	var disabled stringList
+	flag.Var(&disabled, "disable", "disable a rule by id (repeatable)")
+	var registries stringList
+	flag.Var(&registries, "registry", "allowed image registry prefix (repeatable, default "+validator.DefaultRegistry+")")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: yamlvalid [flags] <path_to_yaml|dir|-> [<path_to_yaml|dir>...]")
+		flag.PrintDefaults()
+		fmt.Fprintln(os.Stderr, "Exit codes: 0 - valid, 1 - validation errors, 2 - usage, read or parse errors")
 	}
+	flag.Parse()
 
-	path := os.Args[1]
-	content, err := os.ReadFile(path)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s: cannot read file: %v\n", path, err)
-		os.Exit(1)
+	if *showVersion {
+		fmt.Printf("yamlvalid %s (commit %s, built %s)\n", version, commit, date)
+		os.Exit(exitOK)
 	}
-
-	var root yaml.Node
-	if err := yaml.Unmarshal(content, &root); err != nil {
-		fmt.Fprintf(os.Stderr, "%s: cannot parse YAML: %v\n", path, err)
-		os.Exit(1)
+	if *listRules {
+		printRules()
+		os.Exit(exitOK)
 	}
-
-	errorsFound := traversePod(path, &root)
-	if len(errorsFound) > 0 {
-		for _, e := range errorsFound {
-			fmt.Fprintln(os.Stderr, e)
+	if *explain != "" {
+		text, ok := explainRule(*explain)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown rule '%s'\n", *explain)
+			os.Exit(exitBroken)
 		}
-		os.Exit(1)
+		fmt.Printf("%s: %s\n", *explain, text)
+		os.Exit(exitOK)
 	}
 
-	// Если ошибок нет
-	os.Exit(0)
-}
-
-// ---------- Валидация Pod ----------
-
-func traversePod(filename string, root *yaml.Node) []string {
-	var errorsFound []string
-
-	if len(root.Content) == 0 {
-		errorsFound = append(errorsFound, fmt.Sprintf("%s: empty YAML document", filename))
-		return errorsFound
+	if *format != "text" && *format != "json" && *format != "sarif" {
+		fmt.Fprintf(os.Stderr, "unsupported format '%s'\n", *format)
+		flag.Usage()
+		os.Exit(exitBroken)
 	}
-
-	doc := root.Content[0] // Берём первый документ
-	m := nodeMap(doc)
-
-	// apiVersion
-	if n, ok := m["apiVersion"]; ok {
-		if n.Value != "v1" {
-			errorsFound = append(errorsFound, fmt.Sprintf("%s:%d apiVersion has unsupported value '%s'", filename, n.Line, n.Value))
-		}
-	} else {
-		errorsFound = append(errorsFound, fmt.Sprintf("apiVersion is required"))
+	if *input != "auto" && *input != "yaml" && *input != "json" {
+		fmt.Fprintf(os.Stderr, "unsupported input format '%s'\n", *input)
+		flag.Usage()
+		os.Exit(exitBroken)
+	}
+	if *pathMode != "relative" && *pathMode != "absolute" {
+		fmt.Fprintf(os.Stderr, "unsupported path mode '%s'\n", *pathMode)
+		flag.Usage()
+		os.Exit(exitBroken)
+	}
+	if *jobs < 1 {
+		fmt.Fprintln(os.Stderr, "--jobs must be at least 1")
+		os.Exit(exitBroken)
+	}
+	color, err := useColor(*colorMode)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(exitBroken)
 	}
 
-	// kind
-	if n, ok := m["kind"]; ok {
-		if n.Value != "Pod" {
-			errorsFound = append(errorsFound, fmt.Sprintf("%s:%d kind has unsupported value '%s'", filename, n.Line, n.Value))
+	paths := flag.Args()
+	if *filesFrom != "" {
+		listed, err := readFileList(*filesFrom)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cannot read file list: %v\n", err)
+			os.Exit(exitBroken)
 		}
-	} else {
-		errorsFound = append(errorsFound, "kind is required")
+		paths = append(paths, listed...)
+	} else if len(paths) == 0 {
+		// Без аргументов читаем stdin, но только если туда что-то передают
+		if !stdinIsPipe() {
+			flag.Usage()
+			os.Exit(exitBroken)
+		}
+		paths = []string{"-"}
 	}
-
-	// metadata
-	metaNode, ok := m["metadata"]
-	if !ok {
-		errorsFound = append(errorsFound, "metadata is required")
-	} else {
-		errorsFound = append(errorsFound, traverseMetadata(filename, metaNode)...)
+	paths, unmatched, err := expandPaths(paths, *recursive)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot scan directory: %v\n", err)
+		os.Exit(exitBroken)
 	}
-
-	// spec
-	specNode, ok := m["spec"]
-	if !ok {
-		errorsFound = append(errorsFound, "spec is required")
-	} else {
-		errorsFound = append(errorsFound, traverseSpec(filename, specNode)...)
+	for i, path := range paths {
+		paths[i] = normalizePath(path, *pathMode)
 	}
 
-	return errorsFound
-}
-
-// ---------- Metadata ----------
-
-func traverseMetadata(filename string, meta *yaml.Node) []string {
-	var errorsFound []string
-	m := nodeMap(meta)
-
-	// name
-	if n, ok := m["name"]; !ok || n.Value == "" {
-		errorsFound = append(errorsFound, "metadata.name is required")
+	rules, err := loadRules(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "cannot load config: %v\n", err)
+		os.Exit(exitBroken)
 	}
-
-	// namespace и labels необязательны, проверка типов не обязательна
-	return errorsFound
-}
-
-// ---------- Spec ----------
-
-func traverseSpec(filename string, spec *yaml.Node) []string {
-	var errorsFound []string
-	m := nodeMap(spec)
-
-	// os
-	if osNode, ok := m["os"]; ok {
-		if osNode.Kind == yaml.ScalarNode {
-			if osNode.Value != "linux" && osNode.Value != "windows" {
-				errorsFound = append(errorsFound, fmt.Sprintf("%s:%d spec.os has unsupported value '%s'", filename, osNode.Line, osNode.Value))
-			}
-		}
+	if len(registries) > 0 {
+		rules.Registries = registries
 	}
-
-	// containers
-	contNode, ok := m["containers"]
-	if !ok {
-		errorsFound = append(errorsFound, "spec.containers is required")
-		return errorsFound
+	for _, rule := range disabled {
+		if _, ok := explainRule(rule); !ok {
+			fmt.Fprintf(os.Stderr, "unknown rule '%s'\n", rule)
+			os.Exit(exitBroken)
+		}
+		rules.DisabledRules = append(rules.DisabledRules, rule)
 	}
-	if contNode.Kind != yaml.SequenceNode {
-		errorsFound = append(errorsFound, fmt.Sprintf("%s:%d spec.containers must be a list", filename, contNode.Line))
-		return errorsFound
+	if *containerName != "" {
+		rules.ContainerName = *containerName
+		if err := rules.Compile(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitBroken)
+		}
 	}
 
-	for _, c := range contNode.Content {
-		errorsFound = append(errorsFound, traverseContainer(filename, c)...)
+	v := &validator.Validator{Strict: *strict, Rules: rules, DisallowLatest: *disallowLatest, SchemaOnly: *schemaOnly, RequireLimits: *requireLimits, WarnMissingProbes: *warnMissingProbes}
+	if *kinds != "" {
+		for _, kind := range strings.Split(*kinds, ",") {
+			kind = strings.TrimSpace(kind)
+			if !slices.Contains(validator.SupportedKinds(), kind) {
+				fmt.Fprintf(os.Stderr, "unsupported kind '%s'\n", kind)
+				os.Exit(exitBroken)
+			}
+			v.Kinds = append(v.Kinds, kind)
+		}
 	}
-
-	return errorsFound
-}
-
-// ---------- Container ----------
-
-func traverseContainer(filename string, c *yaml.Node) []string {
-	var errorsFound []string
-	m := nodeMap(c)
-
-	// name
-	if n, ok := m["name"]; !ok || n.Value == "" {
-		errorsFound = append(errorsFound, "containers.name is required")
-	} else {
-		matched, _ := regexp.MatchString(`^[a-z0-9_]+$`, n.Value)
-		if !matched {
-			errorsFound = append(errorsFound, fmt.Sprintf("%s:%d containers.name has invalid format '%s'", filename, n.Line, n.Value))
+	if *schemaPath != "" {
+		if v.Schema, err = validator.LoadSchema(*schemaPath); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot load schema: %v\n", err)
+			os.Exit(exitBroken)
 		}
+	} else if *schemaOnly {
+		fmt.Fprintln(os.Stderr, "--schema-only requires --schema")
+		os.Exit(exitBroken)
 	}
 
-	// image
-	if n, ok := m["image"]; !ok || n.Value == "" {
-		errorsFound = append(errorsFound, "containers.image is required")
-	} else {
-		if !strings.HasPrefix(n.Value, "registry.bigbrother.io/") {
-			errorsFound = append(errorsFound, fmt.Sprintf("%s:%d containers.image has unsupported value '%s'", filename, n.Line, n.Value))
+	var all []validator.Finding
+	checked, failed, broken := 0, 0, 0
+	// Пустой шаблон или каталог — не ошибка, если только не включён --strict
+	for _, pattern := range unmatched {
+		if rules.RuleDisabled(ruleNoMatch) {
+			break
 		}
-		if !strings.Contains(n.Value, ":") {
-			errorsFound = append(errorsFound, fmt.Sprintf("%s:%d containers.image must include tag", filename, n.Line))
+		f := validator.Finding{File: pattern, Rule: ruleNoMatch, Severity: validator.SeverityWarning, Message: "pattern matched no files"}
+		if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+			f.Message = "directory contains no .yaml, .yml or .json files"
 		}
+		if *strict {
+			f.Severity = validator.SeverityError
+			broken++
+		}
+		all = append(all, f)
 	}
-
-	// ports (необязательные)
-	if portsNode, ok := m["ports"]; ok && portsNode.Kind == yaml.SequenceNode {
-		for _, p := range portsNode.Content {
-			errorsFound = append(errorsFound, traversePort(filename, p)...)
+	// Ctrl+C прерывает проверку большого набора файлов
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// С --fail-fast проверяем последовательно, чтобы остановиться на
+	// первом файле с ошибками
+	var results []fileResult
+	if !*failFast {
+		results = validateFiles(ctx, v, paths, *input, *jobs)
+	}
+	for i, path := range paths {
+		var errorsFound []validator.Finding
+		var ok bool
+		if results != nil {
+			errorsFound, ok = results[i].findings, results[i].ok
+		} else {
+			errorsFound, ok = validateFile(ctx, v, path, *input)
+		}
+		checked++
+		if !ok {
+			broken++
+		}
+		result := validator.NewResult(errorsFound)
+		if result.HasErrors() {
+			failed++
+		}
+		if *failFast && result.HasErrors() {
+			// Остальные файлы не проверяем, из текущего оставляем первую ошибку
+			all = append(all, upToFirstError(errorsFound)...)
+			break
 		}
+		all = append(all, errorsFound...)
 	}
 
-	// readinessProbe
-	if rNode, ok := m["readinessProbe"]; ok {
-		errorsFound = append(errorsFound, traverseProbe(filename, rNode, "readinessProbe")...)
+	if ctx.Err() != nil {
+		fmt.Fprintln(os.Stderr, "interrupted")
+		os.Exit(exitBroken)
 	}
 
-	// livenessProbe
-	if lNode, ok := m["livenessProbe"]; ok {
-		errorsFound = append(errorsFound, traverseProbe(filename, lNode, "livenessProbe")...)
+	switch {
+	case quiet:
+		// Только код завершения
+	case *format == "json":
+		printJSON(all, *maxErrors)
+	case *format == "sarif":
+		printSARIF(all)
+	default:
+		shown, more := limitFindings(all, *maxErrors)
+		printText(os.Stderr, shown, color)
+		if more > 0 {
+			fmt.Fprintf(os.Stderr, "... and %d more\n", more)
+		}
+		printSummary(os.Stderr, all)
+		// Сводка имеет смысл только при проверке нескольких файлов
+		if len(paths) > 1 {
+			line := fmt.Sprintf("%s, %d with errors", plural(checked, "file"), failed)
+			// --fail-fast оставляет часть файлов непроверенными
+			if skipped := len(paths) - checked; skipped > 0 {
+				line += fmt.Sprintf(" (%d not checked)", skipped)
+			}
+			fmt.Fprintln(os.Stderr, line)
+		}
 	}
 
-	// resources
-	if resNode, ok := m["resources"]; ok {
-		errorsFound = append(errorsFound, traverseResources(filename, resNode)...)
-	} else {
-		errorsFound = append(errorsFound, "containers.resources is required")
+	if *bitmaskExit {
+		os.Exit(exitBitmask(all, broken))
 	}
 
-	return errorsFound
-}
+	switch {
+	case broken > 0:
+		os.Exit(exitBroken)
+	case failed > 0:
+		os.Exit(exitInvalid)
+	}
 
-// ---------- ContainerPort ----------
+	// Если ошибок нет
+	os.Exit(exitOK)
+}
 
-func traversePort(filename string, port *yaml.Node) []string {
-	var errorsFound []string
-	m := nodeMap(port)
+// Биты кода завершения для --bitmask-exit.
+const (
+	exitBitErrors   = 1 << 0
+	exitBitWarnings = 1 << 1
+	exitBitBroken   = 1 << 2
+)
 
-	// containerPort
-	if n, ok := m["containerPort"]; !ok {
-		errorsFound = append(errorsFound, fmt.Sprintf("%s:%d containerPort is required", filename, port.Line))
-	} else {
-		if _, err := strconv.Atoi(n.Value); err != nil {
-			errorsFound = append(errorsFound, fmt.Sprintf("%s:%d containerPort must be int", filename, n.Line))
-		} else if v, _ := strconv.Atoi(n.Value); v <= 0 || v >= 65536 {
-			errorsFound = append(errorsFound, fmt.Sprintf("%s:%d containerPort value out of range", filename, n.Line))
-		}
+func exitBitmask(findings []validator.Finding, broken int) int {
+	result := validator.NewResult(findings)
+	code := 0
+	if result.HasErrors() {
+		code |= exitBitErrors
 	}
-
-	// protocol
-	if n, ok := m["protocol"]; ok {
-		if n.Value != "TCP" && n.Value != "UDP" {
-			errorsFound = append(errorsFound, fmt.Sprintf("%s:%d protocol has unsupported value '%s'", filename, n.Line, n.Value))
-		}
+	if len(result.Warnings) > 0 {
+		code |= exitBitWarnings
+	}
+	if broken > 0 {
+		code |= exitBitBroken
 	}
+	return code
+}
 
-	return errorsFound
+// cliRuleDescriptions описывает правила, которые сообщает сама утилита.
+var cliRuleDescriptions = map[string]string{
+	ruleReadError:  "The file could not be read. Check the path and the file permissions.",
+	ruleParseError: "The file is not valid YAML (or JSON for .json files and --input json). Fix the syntax near the reported line.",
+	ruleNoMatch:    "A glob pattern matched no files, or a directory contains no .yaml, .yml or .json files. Check the path; in strict mode this is an error.",
 }
 
-// ---------- Probe ----------
+func init() {
+	// Правила утилиты можно отключать и в политике
+	for id := range cliRuleDescriptions {
+		validator.RegisterRule(id)
+	}
+}
 
-func traverseProbe(filename string, probe *yaml.Node, name string) []string {
-	var errorsFound []string
-	m := nodeMap(probe)
-	httpNode, ok := m["httpGet"]
-	if !ok {
-		errorsFound = append(errorsFound, fmt.Sprintf("%s:%d %s.httpGet is required", filename, probe.Line, name))
-		return errorsFound
+// printRules печатает правила валидатора и утилиты по одному на строку.
+func printRules() {
+	rules := validator.ListRules()
+	for id, text := range cliRuleDescriptions {
+		severity := validator.SeverityError
+		if id == ruleNoMatch {
+			// Ошибка только в режиме --strict
+			severity = validator.SeverityWarning
+		}
+		rules = append(rules, validator.RuleInfo{ID: id, Severity: severity, Summary: text})
 	}
-	m2 := nodeMap(httpNode)
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
 
-	// path
-	if n, ok := m2["path"]; !ok || n.Value == "" {
-		errorsFound = append(errorsFound, fmt.Sprintf("%s:%d %s.httpGet.path is required", filename, httpNode.Line, name))
-	} else if !strings.HasPrefix(n.Value, "/") {
-		errorsFound = append(errorsFound, fmt.Sprintf("%s:%d %s.httpGet.path has invalid format '%s'", filename, n.Line, name, n.Value))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, r := range rules {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID, r.Severity, r.Summary)
 	}
+	w.Flush()
+}
 
-	// port
-	if n, ok := m2["port"]; !ok {
-		errorsFound = append(errorsFound, fmt.Sprintf("%s:%d %s.httpGet.port is required", filename, httpNode.Line, name))
-	} else if v, err := strconv.Atoi(n.Value); err != nil {
-		errorsFound = append(errorsFound, fmt.Sprintf("%s:%d %s.httpGet.port must be int", filename, n.Line, name))
-	} else if v <= 0 || v >= 65536 {
-		errorsFound = append(errorsFound, fmt.Sprintf("%s:%d %s.httpGet.port value out of range", filename, n.Line, name))
+func explainRule(rule string) (string, bool) {
+	if text, ok := cliRuleDescriptions[rule]; ok {
+		return text, true
 	}
+	return validator.Explain(rule)
+}
 
-	return errorsFound
+// loadRules читает политику из файла или возвращает политику по умолчанию.
+func loadRules(path string) (*validator.Rules, error) {
+	if path == "" {
+		return validator.ParseRules(nil)
+	}
+	return validator.LoadRules(path)
 }
 
-// ---------- Resources ----------
+// stringList — значение повторяемого флага.
+type stringList []string
 
-func traverseResources(filename string, res *yaml.Node) []string {
-	var errorsFound []string
-	m := nodeMap(res)
+func (l *stringList) String() string { return strings.Join(*l, ",") }
 
-	checkMem := func(v string) bool {
-		return regexp.MustCompile(`^\d+(Gi|Mi|Ki)$`).MatchString(v)
-	}
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
 
-	checkCPU := func(v string) bool {
-		_, err := strconv.Atoi(v)
-		return err == nil
-	}
+type fileResult struct {
+	findings []validator.Finding
+	ok       bool
+}
 
-	for _, kind := range []string{"limits", "requests"} {
-		if node, ok := m[kind]; ok {
-			m2 := nodeMap(node)
-			for k, n := range m2 {
-				switch k {
-				case "cpu":
-					if !checkCPU(n.Value) {
-						errorsFound = append(errorsFound, fmt.Sprintf("%s:%d %s.cpu must be int", filename, n.Line, kind))
-					}
-				case "memory":
-					if !checkMem(n.Value) {
-						errorsFound = append(errorsFound, fmt.Sprintf("%s:%d %s.memory has invalid format '%s'", filename, n.Line, kind, n.Value))
-					}
+// validateFiles проверяет файлы пулом из jobs воркеров. Результаты
+// возвращаются в порядке paths, поэтому вывод не зависит от того,
+// какой файл проверен раньше.
+func validateFiles(ctx context.Context, v *validator.Validator, paths []string, input string, jobs int) []fileResult {
+	results := make([]fileResult, len(paths))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				// После отмены оставшиеся файлы пропускаются
+				if ctx.Err() != nil {
+					continue
 				}
+				results[i].findings, results[i].ok = validateFile(ctx, v, paths[i], input)
 			}
+		}()
+	}
+	for i := range paths {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
+
+// validateFile читает и проверяет один файл. Ошибки чтения и разбора
+// возвращаются как обычные находки, чтобы не прерывать проверку
+// остальных файлов; в этом случае ok равен false.
+// input — формат входа; в режиме auto JSON определяется по расширению.
+func validateFile(ctx context.Context, v *validator.Validator, path, input string) (findings []validator.Finding, ok bool) {
+	content, err := readInput(path)
+	if path == "-" {
+		path = stdinName
+	}
+	if err != nil {
+		return failure(v, validator.Finding{File: path, Rule: ruleReadError, Severity: validator.SeverityError, Message: fmt.Sprintf("cannot read file: %v", err)})
+	}
+
+	if input == "json" || input == "auto" && strings.EqualFold(filepath.Ext(path), ".json") {
+		findings, err = v.ValidateJSONContext(ctx, path, content)
+		if err != nil {
+			return failure(v, validator.Finding{File: path, Line: errorLine(err), Rule: ruleParseError, Severity: validator.SeverityError, Message: fmt.Sprintf("cannot parse JSON: %v", err)})
 		}
+	} else if findings, err = v.ValidateContext(ctx, path, content); err != nil {
+		return failure(v, validator.Finding{File: path, Line: errorLine(err), Rule: ruleParseError, Severity: validator.SeverityError, Message: fmt.Sprintf("cannot parse YAML: %v", err)})
 	}
+	sortFindings(findings)
+	return findings, true
+}
 
-	return errorsFound
+// failure возвращает находку утилиты как неудачную проверку файла. Если
+// её правило отключено, находка отбрасывается и файл не считается
+// сломанным.
+func failure(v *validator.Validator, f validator.Finding) ([]validator.Finding, bool) {
+	if v.Rules != nil && v.Rules.RuleDisabled(f.Rule) {
+		return nil, true
+	}
+	return []validator.Finding{f}, false
 }
 
-// ---------- Утилита ----------
+// upToFirstError отбрасывает находки после первой ошибки.
+func upToFirstError(findings []validator.Finding) []validator.Finding {
+	for i, f := range findings {
+		if !f.IsWarning() {
+			return findings[:i+1]
+		}
+	}
+	return findings
+}
 
-func nodeMap(n *yaml.Node) map[string]*yaml.Node {
-	m := map[string]*yaml.Node{}
-	if n.Kind == yaml.MappingNode {
-		for i := 0; i < len(n.Content); i += 2 {
-			keyNode := n.Content[i]
-			valueNode := n.Content[i+1]
-			m[keyNode.Value] = valueNode
+// sortFindings упорядочивает находки одного файла по позиции и тексту,
+// чтобы вывод не зависел от порядка обхода.
+func sortFindings(findings []validator.Finding) {
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Line != findings[j].Line {
+			return findings[i].Line < findings[j].Line
+		}
+		if findings[i].Column != findings[j].Column {
+			return findings[i].Column < findings[j].Column
 		}
+		return findings[i].Message < findings[j].Message
+	})
+}
+
+var errorLineRe = regexp.MustCompile(`line (\d+)`)
+
+// errorLine извлекает номер строки из ошибки парсера YAML, если он есть.
+func errorLine(err error) int {
+	m := errorLineRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
 	}
-	return m
+	line, _ := strconv.Atoi(m[1])
+	return line
 }
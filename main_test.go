@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/igork0006/go-magistr-lesson2-tpl/validator"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/*.expected with the current output")
+
+// TestGolden сверяет текстовый вывод для каждого testdata/*.yaml
+// с соседним .expected.
+func TestGolden(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no fixtures in testdata")
+	}
+
+	for _, path := range paths {
+		name := filepath.Base(path)
+		t.Run(name, func(t *testing.T) {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// В эталонах имена файлов относительны каталога testdata
+			findings, err := validator.Validate(name, content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sortFindings(findings)
+
+			var got bytes.Buffer
+			printText(&got, findings, false)
+			printSummary(&got, findings)
+
+			expectedPath := strings.TrimSuffix(path, ".yaml") + ".expected"
+			if *update {
+				if err := os.WriteFile(expectedPath, got.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(expectedPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != string(want) {
+				t.Errorf("output differs from %s\n--- want\n%s--- got\n%s", expectedPath, want, got.String())
+			}
+		})
+	}
+}
+
+// BenchmarkValidateFiles сравнивает последовательную и параллельную
+// проверку каталога манифестов.
+func BenchmarkValidateFiles(b *testing.B) {
+	content, err := os.ReadFile(filepath.Join("testdata", "pod-valid.yaml"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	dir := b.TempDir()
+	var paths []string
+	for i := 0; i < 200; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("pod-%03d.yaml", i))
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			b.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	jobsList := []int{1}
+	if n := runtime.NumCPU(); n > 1 {
+		jobsList = append(jobsList, n)
+	}
+	for _, jobs := range jobsList {
+		b.Run(fmt.Sprintf("jobs=%d", jobs), func(b *testing.B) {
+			v := &validator.Validator{}
+			for i := 0; i < b.N; i++ {
+				validateFiles(context.Background(), v, paths, "auto", jobs)
+			}
+		})
+	}
+}
+
+func TestDisabledCLIRules(t *testing.T) {
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "broken.yaml")
+	if err := os.WriteFile(broken, []byte("kind: [Pod\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing.yaml")
+
+	rules, err := validator.ParseRules([]byte("disabledRules: [parse-error, read-error]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{broken, missing} {
+		findings, ok := validateFile(context.Background(), &validator.Validator{}, path, "auto")
+		if ok || len(findings) != 1 {
+			t.Errorf("%s: got %v, ok %v; want one finding", path, findings, ok)
+		}
+		findings, ok = validateFile(context.Background(), &validator.Validator{Rules: rules}, path, "auto")
+		if !ok || len(findings) != 0 {
+			t.Errorf("%s with rule disabled: got %v, ok %v; want nothing", path, findings, ok)
+		}
+	}
+}
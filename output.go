@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/igork0006/go-magistr-lesson2-tpl/validator"
+)
+
+// ---------- Вывод ----------
+
+const (
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+	ansiReset  = "\x1b[0m"
+)
+
+// useColor решает, раскрашивать ли текстовый вывод; в режиме auto — только
+// если stderr является терминалом.
+func useColor(mode string) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		info, err := os.Stderr.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("unsupported color mode '%s'", mode)
+	}
+}
+
+func printText(w io.Writer, findings []validator.Finding, color bool) {
+	for _, f := range findings {
+		if !color {
+			fmt.Fprintln(w, f)
+			continue
+		}
+
+		msg := ansiRed + f.Message + ansiReset
+		if f.IsWarning() {
+			msg = ansiYellow + "warning: " + f.Message + ansiReset
+		}
+		sep := " "
+		if f.Line == 0 {
+			sep = ": "
+		}
+		fmt.Fprintln(w, ansiCyan+f.Location()+ansiReset+sep+msg)
+	}
+}
+
+// summary — сводка находок по правилам.
+type summary struct {
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+	Rules    map[string]int `json:"rules"`
+}
+
+func summarize(findings []validator.Finding) summary {
+	sum := summary{Rules: map[string]int{}}
+	for _, f := range findings {
+		if f.IsWarning() {
+			sum.Warnings++
+		} else {
+			sum.Errors++
+		}
+		sum.Rules[f.Rule]++
+	}
+	return sum
+}
+
+// printSummary печатает сводку вида "5 errors, 1 warning: 2 required, 1 cpu-format";
+// самые частые правила идут первыми.
+func printSummary(w io.Writer, findings []validator.Finding) {
+	if len(findings) == 0 {
+		return
+	}
+	sum := summarize(findings)
+	rules := make([]string, 0, len(sum.Rules))
+	for rule := range sum.Rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if sum.Rules[rules[i]] != sum.Rules[rules[j]] {
+			return sum.Rules[rules[i]] > sum.Rules[rules[j]]
+		}
+		return rules[i] < rules[j]
+	})
+
+	parts := make([]string, len(rules))
+	for i, rule := range rules {
+		parts[i] = fmt.Sprintf("%d %s", sum.Rules[rule], rule)
+	}
+	fmt.Fprintf(w, "%s, %s: %s\n", plural(sum.Errors, "error"), plural(sum.Warnings, "warning"), strings.Join(parts, ", "))
+}
+
+// plural возвращает "1 error", "2 errors", "0 errors".
+func plural(n int, word string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, word)
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
+
+// limitFindings оставляет не больше limit находок (0 — без ограничения)
+// и возвращает число отброшенных.
+func limitFindings(findings []validator.Finding, limit int) ([]validator.Finding, int) {
+	if limit <= 0 || len(findings) <= limit {
+		return findings, 0
+	}
+	return findings[:limit], len(findings) - limit
+}
+
+// printJSON печатает не больше limit находок; сводка всегда считается
+// по всем находкам.
+func printJSON(findings []validator.Finding, limit int) {
+	shown, more := limitFindings(findings, limit)
+	if shown == nil {
+		shown = []validator.Finding{}
+	}
+	report := struct {
+		Findings  []validator.Finding `json:"findings"`
+		Truncated bool                `json:"truncated"`
+		Summary   summary             `json:"summary"`
+	}{shown, more > 0, summarize(findings)}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot encode findings: %v\n", err)
+		os.Exit(exitBroken)
+	}
+}
@@ -0,0 +1,183 @@
+package validator
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- CronJob ----------
+
+var concurrencyPolicies = map[string]bool{"Allow": true, "Forbid": true, "Replace": true}
+
+func (v *Validator) traverseCronJob(filename string, doc *yaml.Node) []Finding {
+	errorsFound := v.traverseObject(filename, doc, "CronJob")
+	m := nodeMap(doc)
+
+	// spec
+	specNode, ok := m["spec"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, doc, ruleRequired, "spec is required"))
+		return errorsFound
+	}
+	sm := nodeMap(specNode)
+
+	// schedule
+	if n, ok := sm["schedule"]; !ok || n.Value == "" {
+		errorsFound = append(errorsFound, newFinding(filename, specNode, ruleRequired, "spec.schedule is required"))
+	} else if !validCronSchedule(n.Value) {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleCronSchedule, "spec.schedule has invalid format '%s'", n.Value))
+	}
+
+	// concurrencyPolicy (необязательное, по умолчанию Allow)
+	if n, ok := sm["concurrencyPolicy"]; ok && !concurrencyPolicies[n.Value] {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleConcurrencyPolicy, "spec.concurrencyPolicy has unsupported value '%s'", n.Value))
+	}
+
+	// jobTemplate.spec.template
+	jobNode, ok := sm["jobTemplate"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, specNode, ruleRequired, "spec.jobTemplate is required"))
+		return errorsFound
+	}
+	jobSpec, ok := nodeMap(jobNode)["spec"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, jobNode, ruleRequired, "spec.jobTemplate.spec is required"))
+		return errorsFound
+	}
+	tmplNode, ok := nodeMap(jobSpec)["template"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, jobSpec, ruleRequired, "spec.jobTemplate.spec.template is required"))
+		return errorsFound
+	}
+
+	return append(errorsFound, v.traverseJobPodTemplate(filename, tmplNode, "spec.jobTemplate.spec.template")...)
+}
+
+// ---------- Job ----------
+
+func (v *Validator) traverseJob(filename string, doc *yaml.Node) []Finding {
+	errorsFound := v.traverseObject(filename, doc, "Job")
+
+	specNode, ok := nodeMap(doc)["spec"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, doc, ruleRequired, "spec is required"))
+		return errorsFound
+	}
+	tmplNode, ok := nodeMap(specNode)["template"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, specNode, ruleRequired, "spec.template is required"))
+		return errorsFound
+	}
+
+	return append(errorsFound, v.traverseJobPodTemplate(filename, tmplNode, "spec.template")...)
+}
+
+// traverseJobPodTemplate проверяет шаблон пода задания: сам pod spec
+// и restartPolicy, допустимый только для Job.
+func (v *Validator) traverseJobPodTemplate(filename string, tmpl *yaml.Node, path string) []Finding {
+	var errorsFound []Finding
+
+	podSpec, ok := nodeMap(tmpl)["spec"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, tmpl, ruleRequired, "%s.spec is required", path))
+		return errorsFound
+	}
+
+	errorsFound = append(errorsFound, v.traverseJobRestartPolicy(filename, podSpec, path+".spec")...)
+	errorsFound = append(errorsFound, v.traverseSpec(filename, podSpec, podPaths(path))...)
+	return errorsFound
+}
+
+// Для Job под не должен перезапускаться бесконечно, поэтому Always
+// (значение по умолчанию для Pod) здесь недопустим.
+func (v *Validator) traverseJobRestartPolicy(filename string, podSpec *yaml.Node, path string) []Finding {
+	var errorsFound []Finding
+
+	n, ok := nodeMap(podSpec)["restartPolicy"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, podSpec, ruleRequired, "%s.restartPolicy is required for jobs (must be OnFailure or Never)", path))
+	} else if n.Value == "Always" {
+		// Прочие недопустимые значения сообщает общая проверка pod spec
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleRestartPolicy, "%s.restartPolicy has unsupported value '%s' (jobs allow only OnFailure or Never)", path, n.Value))
+	}
+
+	return errorsFound
+}
+
+// ---------- Cron ----------
+
+var cronMacros = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+type cronField struct {
+	min, max int
+	names    []string // имена значений, начиная с min (месяцы, дни недели)
+}
+
+var cronFields = []cronField{
+	{min: 0, max: 59}, // минуты
+	{min: 0, max: 23}, // часы
+	{min: 1, max: 31}, // день месяца
+	{min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+func validCronSchedule(schedule string) bool {
+	if strings.HasPrefix(schedule, "@") {
+		return cronMacros[schedule]
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return false
+	}
+	for i, f := range fields {
+		for _, item := range strings.Split(f, ",") {
+			if !validCronItem(item, cronFields[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func validCronItem(item string, f cronField) bool {
+	rng, step, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		if v, err := strconv.Atoi(step); err != nil || v <= 0 {
+			return false
+		}
+	}
+
+	if rng == "*" || rng == "?" {
+		return true
+	}
+
+	lo, hi, isRange := strings.Cut(rng, "-")
+	from, ok := cronValue(lo, f)
+	if !ok {
+		return false
+	}
+	if !isRange {
+		return true
+	}
+	to, ok := cronValue(hi, f)
+	return ok && from <= to
+}
+
+func cronValue(s string, f cronField) (int, bool) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, true
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- Env ----------
+
+var envNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// envRefKeys — обязательное поле для каждого источника valueFrom.
+var envRefKeys = map[string]string{
+	"configMapKeyRef":  "key",
+	"secretKeyRef":     "key",
+	"fieldRef":         "fieldPath",
+	"resourceFieldRef": "resource",
+}
+
+var envRefSources = []string{"configMapKeyRef", "secretKeyRef", "fieldRef", "resourceFieldRef"}
+
+// Поля пода, доступные через downward API в fieldRef.
+var envFieldPaths = keySet(
+	"metadata.name", "metadata.namespace", "metadata.uid",
+	"spec.nodeName", "spec.serviceAccountName",
+	"status.hostIP", "status.hostIPs", "status.podIP", "status.podIPs",
+)
+
+// Метки и аннотации адресуются по ключу: metadata.labels['app']
+var envFieldPathKeyRe = regexp.MustCompile(`^metadata\.(labels|annotations)\['[^']+'\]$`)
+
+// Ресурсы контейнера, доступные в resourceFieldRef.
+var envResources = keySet(
+	"limits.cpu", "limits.memory", "limits.ephemeral-storage",
+	"requests.cpu", "requests.memory", "requests.ephemeral-storage",
+)
+
+func (v *Validator) traverseEnv(filename string, env *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	if isNull(env) {
+		errorsFound = append(errorsFound, newFinding(filename, env, ruleNull, "%s.env must not be null", p.container))
+		return errorsFound
+	}
+	if env.Kind != yaml.SequenceNode {
+		errorsFound = append(errorsFound, newFinding(filename, env, ruleType, "%s.env must be a list", p.container))
+		return errorsFound
+	}
+
+	for _, e := range env.Content {
+		errorsFound = append(errorsFound, v.traverseEnvVar(filename, e, p)...)
+	}
+	return errorsFound
+}
+
+func (v *Validator) traverseEnvVar(filename string, e *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	m := nodeMap(e)
+
+	// name
+	nameNode, ok := m["name"]
+	if !ok || nameNode.Value == "" {
+		errorsFound = append(errorsFound, newFinding(filename, e, ruleRequired, "%senv.name is required", p.inner))
+	} else if !envNameRe.MatchString(nameNode.Value) {
+		errorsFound = append(errorsFound, newFinding(filename, nameNode, ruleEnvName, "%senv.name has invalid format '%s'", p.inner, nameNode.Value))
+	}
+
+	// value / valueFrom
+	sources := presentKeys(m, "value", "valueFrom")
+	if len(sources) != 1 {
+		errorsFound = append(errorsFound, newFinding(filename, e, ruleEnvSource, "%senv must specify exactly one of value, valueFrom", p.inner))
+		return errorsFound
+	}
+	fromNode, ok := m["valueFrom"]
+	if !ok {
+		return errorsFound
+	}
+
+	fm := nodeMap(fromNode)
+	refs := presentKeys(fm, envRefSources...)
+	if len(refs) != 1 {
+		errorsFound = append(errorsFound, newFinding(filename, fromNode, ruleEnvSource, "%senv.valueFrom must specify exactly one of %s", p.inner, strings.Join(envRefSources, ", ")))
+		return errorsFound
+	}
+
+	ref := refs[0]
+	refNode := fm[ref]
+	key := envRefKeys[ref]
+	n, ok := nodeMap(refNode)[key]
+	switch {
+	case !ok || n.Value == "":
+		errorsFound = append(errorsFound, newFinding(filename, refNode, ruleRequired, "%senv.valueFrom.%s.%s is required", p.inner, ref, key))
+	case ref == "fieldRef" && !envFieldPaths[n.Value] && !envFieldPathKeyRe.MatchString(n.Value):
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleEnvSource, "%senv.valueFrom.fieldRef.fieldPath has unsupported value '%s'", p.inner, n.Value))
+	case ref == "resourceFieldRef" && !envResources[n.Value] && !strings.HasPrefix(n.Value, "limits.hugepages-") && !strings.HasPrefix(n.Value, "requests.hugepages-"):
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleEnvSource, "%senv.valueFrom.resourceFieldRef.resource has unsupported value '%s'", p.inner, n.Value))
+	}
+
+	return errorsFound
+}
+
+// ---------- EnvFrom ----------
+
+var envFromSources = []string{"configMapRef", "secretRef"}
+
+func (v *Validator) traverseEnvFrom(filename string, envFrom *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	if isNull(envFrom) {
+		errorsFound = append(errorsFound, newFinding(filename, envFrom, ruleNull, "%s.envFrom must not be null", p.container))
+		return errorsFound
+	}
+	if envFrom.Kind != yaml.SequenceNode {
+		errorsFound = append(errorsFound, newFinding(filename, envFrom, ruleType, "%s.envFrom must be a list", p.container))
+		return errorsFound
+	}
+
+	for _, e := range envFrom.Content {
+		m := nodeMap(e)
+
+		// prefix (необязательный)
+		if n, ok := m["prefix"]; ok && (n.Kind != yaml.ScalarNode || n.Tag != "!!str") {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%senvFrom.prefix must be string", p.inner))
+		}
+
+		// configMapRef / secretRef
+		refs := presentKeys(m, envFromSources...)
+		if len(refs) != 1 {
+			errorsFound = append(errorsFound, newFinding(filename, e, ruleEnvSource, "%senvFrom must specify exactly one of %s", p.inner, strings.Join(envFromSources, ", ")))
+			continue
+		}
+		refNode := m[refs[0]]
+		if n, ok := nodeMap(refNode)["name"]; !ok || n.Value == "" {
+			errorsFound = append(errorsFound, newFinding(filename, refNode, ruleRequired, "%senvFrom.%s.name is required", p.inner, refs[0]))
+		}
+	}
+	return errorsFound
+}
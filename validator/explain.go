@@ -0,0 +1,140 @@
+package validator
+
+import (
+	"sort"
+	"strings"
+)
+
+// ---------- Описания правил ----------
+
+// ruleDescriptions объясняет каждое правило и способ исправления; текст
+// выводится по --explain.
+var ruleDescriptions = map[string]string{
+	ruleEmptyDocument:     "The file contains no YAML documents. Add a manifest or remove the file from the input.",
+	ruleRequired:          "A field Kubernetes requires is missing or empty. Add the field named in the message.",
+	ruleType:              "A field has the wrong YAML type, for example a string where a list or an integer is expected. Rewrite the value in the type named in the message.",
+	ruleNull:              "A field is explicitly set to null. Either give it a value of the expected type or remove the key.",
+	ruleAPIVersion:        "The apiVersion does not match the kind: Pod uses v1, Deployment and StatefulSet use apps/v1, Job and CronJob use batch/v1.",
+	ruleKind:              "The kind is not supported or not allowed. Supported kinds are Pod, Deployment, StatefulSet, Job and CronJob; --kinds can narrow the list.",
+	ruleCronSchedule:      "spec.schedule must be a five-field cron expression (minute, hour, day of month, month, day of week) or a macro such as @daily.",
+	ruleConcurrencyPolicy: "spec.concurrencyPolicy must be Allow, Forbid or Replace.",
+	ruleRestartPolicy:     "restartPolicy must be Always, OnFailure or Never. Pods created by jobs must use OnFailure or Never.",
+	ruleHostAlias:         "Each spec.hostAliases entry needs an ip that is a valid IPv4 or IPv6 address and a non-empty hostnames list of DNS-1123 subdomains.",
+	ruleDNSPolicy:         "spec.dnsPolicy must be ClusterFirst, ClusterFirstWithHostNet, Default or None. With None the pod gets no cluster DNS, so spec.dnsConfig.nameservers must list at least one nameserver.",
+	rulePriority:          "spec.priorityClassName must be a DNS-1123 subdomain. spec.priority is filled in by the cluster from the priority class, so setting it by hand is reported as a warning.",
+	ruleReplicas:          "spec.replicas must be a non-negative integer.",
+	ruleLabel:             "Label keys are an optional DNS subdomain prefix followed by '/' and a name of at most 63 characters; values are at most 63 alphanumerics, '-', '_' or '.', starting and ending with an alphanumeric.",
+	ruleAnnotation:        "Annotation keys follow the same rules as label keys: an optional DNS subdomain prefix and '/', then a name of at most 63 characters. Values are not checked.",
+	ruleObjectName:        "metadata.name must be a DNS-1123 subdomain: lowercase alphanumerics, '-' or '.', at most 253 characters, starting and ending with an alphanumeric. metadata.generateName follows the same rules but may end with '-'.",
+	ruleNamespace:         "metadata.namespace must be a DNS-1123 label: lowercase alphanumerics or '-', at most 63 characters, starting and ending with an alphanumeric.",
+	ruleServiceAccount:    "spec.serviceAccountName must be a DNS-1123 subdomain: lowercase alphanumerics, '-' or '.', starting and ending with an alphanumeric. Underscores are not allowed.",
+	ruleDuplicateKey:      "The same key appears twice in one mapping. YAML parsers keep only the last value and the API server rejects the manifest; remove one of the keys.",
+	ruleUnknownField:      "The field is not part of the Kubernetes schema at this level, usually because of a typo or wrong indentation. Reported only in strict mode.",
+	ruleJSONSchema:        "The document does not match the JSON Schema passed with --schema. The message contains the JSON pointer of the offending value.",
+	ruleEnvName:           "Environment variable names must consist of letters, digits and '_', and must not start with a digit.",
+	ruleEnvSource:         "An env entry must set exactly one of value or valueFrom, and valueFrom must reference exactly one source with its required keys. An envFrom entry must set exactly one of configMapRef or secretRef.",
+	ruleVolumeMount:       "Each volumeMount must reference a volume declared in spec.volumes by name.",
+	ruleVolume:            "Each volume needs a unique name and exactly one source (emptyDir, configMap, secret, hostPath, ...) with its required fields.",
+	ruleOS:                "spec.os.name must be an operating system allowed by the policy (linux or windows by default). The legacy scalar form 'os: linux' is deprecated, and fields Windows does not support, such as hostPID, are reported for windows pods.",
+	ruleToleration:        "A toleration's operator must be Exists or Equal, value is only allowed with Equal, effect must be NoSchedule, PreferNoSchedule, NoExecute or empty, and tolerationSeconds requires effect NoExecute.",
+	ruleAffinity:          "spec.affinity has the wrong shape: required node affinity needs a non-empty nodeSelectorTerms list, pod affinity terms need a topologyKey, and preferred terms need a weight between 1 and 100.",
+	ruleContainerName:     "Container names must be DNS-1123 labels (or match the pattern set by --container-name-pattern or the config).",
+	ruleDuplicateName:     "Container names must be unique across containers and initContainers of a pod.",
+	ruleImageRegistry:     "Images must come from an allowed registry. Prefix the image with the registry, or allow it with --registry or the config.",
+	ruleImageTag:          "Images must be pinned with an explicit tag or a digest.",
+	ruleImageLatest:       "The image uses the 'latest' tag, so deployments are not reproducible. Pin a version tag or a digest. This is a warning unless --strict, --disallow-latest or the severities config makes it an error.",
+	ruleImageDigest:       "An image digest must look like sha256: followed by 64 lowercase hex characters.",
+	ruleImagePullPolicy:   "imagePullPolicy must be Always, IfNotPresent or Never. Images tagged 'latest' should use Always, otherwise nodes run a stale cached image.",
+	ruleWorkingDir:        "containers.workingDir must be an absolute path starting with '/'.",
+	rulePortRange:         "Port numbers must be integers between 1 and 65535.",
+	ruleHostPortPrivilege: "In strict mode, a hostPort below 1024 is reported because clusters often forbid binding privileged ports on the node. It stays a warning even in strict mode.",
+	ruleDuplicatePort:     "Two containers of a pod expose the same containerPort and protocol. Change one of the ports.",
+	rulePortName:          "Port names must be unique within a container and be lowercase DNS-1123 labels of at most 15 characters containing at least one letter.",
+	ruleProtocol:          "Port protocol must be TCP or UDP.",
+	ruleProbePath:         "httpGet.path of a probe must be an absolute path starting with '/'.",
+	ruleProbeHandler:      "A probe must specify exactly one handler: httpGet, exec, tcpSocket or grpc. Lifecycle hooks accept httpGet, exec or tcpSocket.",
+	ruleProbeScheme:       "httpGet.scheme of a probe must be HTTP or HTTPS.",
+	ruleProbeHost:         "httpGet.host of a probe must be a hostname or an IP address. It defaults to the pod IP, so setting it is usually a mistake and is reported as a warning.",
+	ruleProbePort:         "A probe port given by name must match the name of one of the container's ports.",
+	ruleProbeTiming:       "Probe timings must be non-negative integers; liveness and startup probes require successThreshold: 1.",
+	ruleInitProbe:         "Init containers run to completion before the pod starts, so probes on them are ignored. Remove the probe.",
+	ruleIdenticalProbes:   "A container's readinessProbe and livenessProbe are identical. When the container is slow under load it is both taken out of rotation and restarted, which can cascade across replicas. Give livenessProbe a more lenient threshold or a cheaper check.",
+	ruleMissingProbe:      "With --warn-missing-probes, a container defines neither a readinessProbe nor a livenessProbe, so Kubernetes cannot tell when it is ready or stuck. Add at least one probe.",
+	ruleSecurityContext:   "securityContext.runAsUser and runAsGroup must not be negative. Values of the wrong type, such as a string for privileged, are reported as invalid-type.",
+	rulePrivileged:        "In strict mode, a container with securityContext.privileged: true is reported, since it has full access to the node. It stays a warning even in strict mode.",
+	ruleRunAsNonRoot:      "In strict mode, a container is reported when runAsNonRoot is set neither in its securityContext nor in the pod securityContext. It stays a warning even in strict mode.",
+	ruleCPU:               "CPU quantities are a number of cores such as 1 or 0.5, or millicores such as 500m, and must be greater than zero.",
+	ruleExtendedResource:  "Extended resources such as nvidia.com/gpu must be named <domain>/<name> and requested in whole units.",
+	ruleUnknownResource:   "In strict mode, a resource under limits or requests that is neither cpu, memory, ephemeral-storage, hugepages-<size> nor an extended <domain>/<name> resource is reported, usually because of a typo. It stays a warning even in strict mode.",
+	ruleMemory:            "Memory, ephemeral-storage and hugepages quantities are a number of bytes with an optional suffix: k, M, G, T, P, E or Ki, Mi, Gi, Ti, Pi, Ei. Memory must be greater than zero.",
+	ruleRequestsLimits:    "A resource request is larger than the limit of the same resource. Lower the request or raise the limit.",
+}
+
+// externalRules — правила, которые сообщает вызывающая программа, а не
+// Validator (см. RegisterRule).
+var externalRules = map[string]bool{}
+
+// RegisterRule объявляет правило вызывающей программы, например ошибку
+// чтения файла, чтобы его можно было указать в DisabledRules. Вызывать
+// до разбора политики, обычно в init.
+func RegisterRule(id string) {
+	externalRules[id] = true
+}
+
+// knownRule сообщает, что правило rule известно валидатору или
+// объявлено через RegisterRule.
+func knownRule(rule string) bool {
+	_, ok := ruleDescriptions[rule]
+	return ok || externalRules[rule]
+}
+
+// Explain возвращает описание правила rule.
+func Explain(rule string) (string, bool) {
+	text, ok := ruleDescriptions[rule]
+	return text, ok
+}
+
+// ruleSeverities — серьёзность правил, которые сообщают не только ошибки.
+// Остальные правила сообщают ошибки.
+var ruleSeverities = map[string]string{
+	ruleImageLatest:       SeverityError + "|" + SeverityWarning,
+	ruleInitProbe:         SeverityWarning,
+	ruleMissingProbe:      SeverityWarning,
+	ruleIdenticalProbes:   SeverityWarning,
+	ruleImagePullPolicy:   SeverityError + "|" + SeverityWarning,
+	ruleOS:                SeverityError + "|" + SeverityWarning,
+	rulePriority:          SeverityError + "|" + SeverityWarning,
+	ruleHostPortPrivilege: SeverityWarning,
+	rulePrivileged:        SeverityWarning,
+	ruleRunAsNonRoot:      SeverityWarning,
+	ruleUnknownResource:   SeverityWarning,
+	ruleProbeHost:         SeverityError + "|" + SeverityWarning,
+}
+
+// RuleInfo описывает правило для --list-rules.
+type RuleInfo struct {
+	ID       string
+	Severity string
+	Summary  string
+}
+
+// ListRules возвращает все правила валидатора, упорядоченные по ID.
+func ListRules() []RuleInfo {
+	infos := make([]RuleInfo, 0, len(ruleDescriptions))
+	for id, text := range ruleDescriptions {
+		severity, ok := ruleSeverities[id]
+		if !ok {
+			severity = SeverityError
+		}
+		infos = append(infos, RuleInfo{ID: id, Severity: severity, Summary: firstSentence(text)})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+func firstSentence(text string) string {
+	if i := strings.Index(text, ". "); i >= 0 {
+		return text[:i+1]
+	}
+	return text
+}
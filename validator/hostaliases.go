@@ -0,0 +1,56 @@
+package validator
+
+import (
+	"net"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- HostAliases ----------
+
+// traverseHostAliases проверяет записи spec.hostAliases, которые kubelet
+// добавляет в /etc/hosts контейнеров.
+func (v *Validator) traverseHostAliases(filename string, aliases *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	if isNull(aliases) {
+		errorsFound = append(errorsFound, newFinding(filename, aliases, ruleNull, "%s.hostAliases must not be null", p.spec))
+		return errorsFound
+	}
+	if aliases.Kind != yaml.SequenceNode {
+		errorsFound = append(errorsFound, newFinding(filename, aliases, ruleType, "%s.hostAliases must be a list", p.spec))
+		return errorsFound
+	}
+
+	for _, alias := range aliases.Content {
+		if alias.Kind != yaml.MappingNode {
+			errorsFound = append(errorsFound, newFinding(filename, alias, ruleType, "%s.hostAliases entries must be mappings", p.spec))
+			continue
+		}
+		m := nodeMap(alias)
+
+		// ip
+		if n, ok := m["ip"]; !ok || n.Value == "" {
+			errorsFound = append(errorsFound, newFinding(filename, alias, ruleRequired, "%shostAliases.ip is required", p.field))
+		} else if net.ParseIP(n.Value) == nil {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleHostAlias, "%shostAliases.ip has invalid format '%s'", p.field, n.Value))
+		}
+
+		// hostnames
+		hostsNode, ok := m["hostnames"]
+		switch {
+		case !ok:
+			errorsFound = append(errorsFound, newFinding(filename, alias, ruleRequired, "%shostAliases.hostnames is required", p.field))
+		case hostsNode.Kind != yaml.SequenceNode:
+			errorsFound = append(errorsFound, newFinding(filename, hostsNode, ruleType, "%shostAliases.hostnames must be a list", p.field))
+		case len(hostsNode.Content) == 0:
+			errorsFound = append(errorsFound, newFinding(filename, hostsNode, ruleRequired, "%shostAliases.hostnames must contain at least one hostname", p.field))
+		default:
+			for _, h := range hostsNode.Content {
+				if !validDNS1123Subdomain(h.Value) {
+					errorsFound = append(errorsFound, newFinding(filename, h, ruleHostAlias, "%shostAliases.hostnames has invalid hostname '%s'", p.field, h.Value))
+				}
+			}
+		}
+	}
+	return errorsFound
+}
@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- Директивы ignore ----------
+
+const ignoreDirective = "yamlvalid:ignore"
+
+// ignoreSet — подавленные правила по номерам строк; пустой набор правил
+// подавляет все находки строки.
+type ignoreSet map[int]map[string]bool
+
+// collectIgnores ищет директивы "# yamlvalid:ignore[=rule,...]" в
+// комментариях. Комментарий в конце строки относится к этой строке,
+// комментарий на отдельной строке — к следующему за ним узлу.
+func collectIgnores(n *yaml.Node, ignores ignoreSet) {
+	for _, comment := range []string{n.LineComment, n.HeadComment} {
+		for _, line := range strings.Split(comment, "\n") {
+			rules, ok := parseIgnore(line)
+			if !ok {
+				continue
+			}
+			if ignores[n.Line] == nil {
+				ignores[n.Line] = map[string]bool{}
+			}
+			for _, rule := range rules {
+				ignores[n.Line][rule] = true
+			}
+			if len(rules) == 0 {
+				ignores[n.Line][""] = true
+			}
+		}
+	}
+	for _, child := range n.Content {
+		collectIgnores(child, ignores)
+	}
+}
+
+// parseIgnore разбирает одну строку комментария.
+func parseIgnore(comment string) ([]string, bool) {
+	text := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(comment), "#"))
+	rest, ok := strings.CutPrefix(text, ignoreDirective)
+	if !ok {
+		return nil, false
+	}
+	if rest == "" {
+		return nil, true
+	}
+	list, ok := strings.CutPrefix(rest, "=")
+	if !ok {
+		return nil, false
+	}
+	var rules []string
+	for _, rule := range strings.Split(list, ",") {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, true
+}
+
+// filter отбрасывает подавленные находки.
+func (ignores ignoreSet) filter(findings []Finding) []Finding {
+	if len(ignores) == 0 {
+		return findings
+	}
+	kept := findings[:0]
+	for _, f := range findings {
+		rules := ignores[f.Line]
+		if rules[""] || rules[f.Rule] {
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept
+}
@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ---------- Image ----------
+
+var digestRe = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// imageRef — разобранная ссылка на образ вида name[:tag][@digest].
+type imageRef struct {
+	name   string
+	tag    string
+	digest string
+}
+
+func parseImage(image string) imageRef {
+	var ref imageRef
+	rest := image
+	if i := strings.Index(rest, "@"); i >= 0 {
+		rest, ref.digest = rest[:i], rest[i+1:]
+	}
+	// Двоеточие до последнего "/" относится к порту реестра, а не к тегу
+	if i := strings.LastIndex(rest, ":"); i > strings.LastIndex(rest, "/") {
+		rest, ref.tag = rest[:i], rest[i+1:]
+	}
+	ref.name = rest
+	return ref
+}
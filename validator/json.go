@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ---------- JSON ----------
+
+// ValidateJSON проверяет манифест в формате JSON. Синтаксис сначала
+// проверяется парсером JSON, который строже YAML (например, не допускает
+// комментариев и одинарных кавычек), затем документ проходит те же
+// проверки, что и YAML: JSON является подмножеством YAML, поэтому строки
+// и столбцы в находках сохраняются.
+func (v *Validator) ValidateJSON(filename string, content []byte) ([]Finding, error) {
+	return v.ValidateJSONContext(context.Background(), filename, content)
+}
+
+// ValidateJSONContext — ValidateJSON с отменой через ctx.
+func (v *Validator) ValidateJSONContext(ctx context.Context, filename string, content []byte) ([]Finding, error) {
+	if err := checkJSON(content); err != nil {
+		return nil, err
+	}
+	return v.ValidateContext(ctx, filename, content)
+}
+
+func checkJSON(content []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			// Пустой файл: о нём сообщит Validate
+			return nil
+		}
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return fmt.Errorf("json: line %d: %s", offsetLine(content, syntaxErr.Offset), syntaxErr)
+		}
+		return fmt.Errorf("json: %w", err)
+	}
+	if dec.More() {
+		return fmt.Errorf("json: line %d: unexpected data after top-level value", offsetLine(content, dec.InputOffset()))
+	}
+	return nil
+}
+
+// offsetLine переводит смещение в байтах в номер строки.
+func offsetLine(content []byte, offset int64) int {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
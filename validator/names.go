@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ---------- Имена Kubernetes ----------
+
+// dns1123LabelPattern — DNS-1123 label: не длиннее 63 символов, строчные
+// буквы, цифры и "-", начинается и заканчивается буквой или цифрой.
+const dns1123LabelPattern = `^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`
+
+var (
+	dns1123LabelRe     = regexp.MustCompile(dns1123LabelPattern)
+	dns1123SubdomainRe = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	labelNameRe        = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	portNameLetterRe   = regexp.MustCompile(`[a-z]`)
+)
+
+const (
+	dns1123SubdomainMaxLen = 253
+	labelMaxLen            = 63
+	portNameMaxLen         = 15
+)
+
+func validDNS1123Label(s string) bool {
+	return dns1123LabelRe.MatchString(s)
+}
+
+func validDNS1123Subdomain(s string) bool {
+	return len(s) <= dns1123SubdomainMaxLen && dns1123SubdomainRe.MatchString(s)
+}
+
+// validGenerateName проверяет префикс metadata.generateName: к нему
+// добавляется случайный суффикс, поэтому завершающий "-" допустим.
+func validGenerateName(s string) bool {
+	if strings.HasSuffix(s, "-") {
+		s = s[:len(s)-1] + "a"
+	}
+	return validDNS1123Subdomain(s)
+}
+
+// validLabelKey проверяет ключ вида [prefix/]name, где prefix — DNS-1123
+// subdomain, а name — не длиннее 63 символов.
+func validLabelKey(key string) bool {
+	name := key
+	if prefix, rest, ok := strings.Cut(key, "/"); ok {
+		if !validDNS1123Subdomain(prefix) {
+			return false
+		}
+		name = rest
+	}
+	return len(name) <= labelMaxLen && labelNameRe.MatchString(name)
+}
+
+// Пустое значение метки допустимо.
+func validLabelValue(value string) bool {
+	return value == "" || len(value) <= labelMaxLen && labelNameRe.MatchString(value)
+}
+
+// validPortName проверяет имя порта (IANA_SVC_NAME): DNS-1123 label не
+// длиннее 15 символов, хотя бы одна буква и без "--".
+func validPortName(s string) bool {
+	return len(s) <= portNameMaxLen && validDNS1123Label(s) &&
+		portNameLetterRe.MatchString(s) && !strings.Contains(s, "--")
+}
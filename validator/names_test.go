@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestContainerName(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"my-app", true},
+		{"web1", true},
+		{"a", true},
+		{"-bad", false},
+		{"bad-", false},
+		{"Bad", false},
+		{"with_underscore", false},
+		{strings.Repeat("a", 64), false},
+	}
+	for _, tt := range tests {
+		manifest := strings.Replace(podManifest, "name: app", "name: "+tt.name, 1)
+		findings := validate(t, manifest)
+		got := true
+		for _, f := range findings {
+			if f.Rule == ruleContainerName {
+				got = false
+			}
+		}
+		if got != tt.want {
+			t.Errorf("container name %q: valid = %v, want %v (findings: %q)", tt.name, got, tt.want, messages(findings))
+		}
+	}
+}
+
+func TestContainerNamePattern(t *testing.T) {
+	rules := DefaultRules()
+	rules.ContainerName = `^[a-z_]+$`
+	if err := rules.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	manifest := strings.Replace(podManifest, "name: app", "name: with_underscore", 1)
+	findings, err := (&Validator{Rules: &rules}).Validate("pod.yaml", []byte(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("findings = %q, want none", messages(findings))
+	}
+}
@@ -0,0 +1,887 @@
+package validator
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- Валидация Pod ----------
+
+// SupportedKinds возвращает kind ресурсов, которые умеет проверять валидатор.
+func SupportedKinds() []string {
+	return []string{"Pod", "Deployment", "StatefulSet", "Job", "CronJob"}
+}
+
+// kindAPIVersions — допустимые apiVersion для каждого поддерживаемого kind.
+var kindAPIVersions = map[string][]string{
+	"Pod":         {"v1"},
+	"Deployment":  {"apps/v1"},
+	"StatefulSet": {"apps/v1"},
+	"Job":         {"batch/v1"},
+	"CronJob":     {"batch/v1"},
+}
+
+func (v *Validator) traversePod(filename string, doc *yaml.Node) []Finding {
+	var errorsFound []Finding
+	m := nodeMap(doc)
+	errorsFound = append(errorsFound, v.traverseUnknownKeys(filename, doc, "object", "")...)
+
+	if n, ok := m["kind"]; ok && v.Kinds != nil && !slices.Contains(v.Kinds, n.Value) {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleKind, "kind '%s' not allowed", n.Value))
+		return errorsFound
+	}
+
+	if n, ok := m["kind"]; ok {
+		switch n.Value {
+		case "CronJob":
+			return append(errorsFound, v.traverseCronJob(filename, doc)...)
+		case "Job":
+			return append(errorsFound, v.traverseJob(filename, doc)...)
+		case "Deployment", "StatefulSet":
+			return append(errorsFound, v.traverseWorkload(filename, doc)...)
+		}
+	}
+
+	// apiVersion
+	errorsFound = append(errorsFound, v.traverseAPIVersion(filename, doc, "Pod")...)
+
+	// kind
+	if n, ok := m["kind"]; ok {
+		if n.Value != "Pod" {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleKind, "kind has unsupported value '%s'", n.Value))
+		}
+	} else {
+		errorsFound = append(errorsFound, newFinding(filename, doc, ruleRequired, "kind is required"))
+	}
+
+	// metadata
+	metaNode, ok := m["metadata"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, doc, ruleRequired, "metadata is required"))
+	} else {
+		errorsFound = append(errorsFound, v.traverseMetadata(filename, metaNode)...)
+	}
+
+	// spec
+	specNode, ok := m["spec"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, doc, ruleRequired, "spec is required"))
+	} else {
+		errorsFound = append(errorsFound, v.traverseSpec(filename, specNode, podPaths(""))...)
+	}
+
+	return errorsFound
+}
+
+// traverseObject проверяет общие для всех ресурсов поля apiVersion и metadata.
+func (v *Validator) traverseObject(filename string, doc *yaml.Node, kind string) []Finding {
+	errorsFound := v.traverseAPIVersion(filename, doc, kind)
+	m := nodeMap(doc)
+
+	// metadata
+	if metaNode, ok := m["metadata"]; ok {
+		errorsFound = append(errorsFound, v.traverseMetadata(filename, metaNode)...)
+	} else {
+		errorsFound = append(errorsFound, newFinding(filename, doc, ruleRequired, "metadata is required"))
+	}
+
+	return errorsFound
+}
+
+// traverseAPIVersion сверяет apiVersion документа с kindAPIVersions.
+func (v *Validator) traverseAPIVersion(filename string, doc *yaml.Node, kind string) []Finding {
+	var errorsFound []Finding
+	n, ok := nodeMap(doc)["apiVersion"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, doc, ruleRequired, "apiVersion is required"))
+		return errorsFound
+	}
+	allowed := kindAPIVersions[kind]
+	switch {
+	case slices.Contains(allowed, n.Value):
+	case kind == "Pod":
+		// Исходная формулировка для Pod сохранена для совместимости
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleAPIVersion, "apiVersion has unsupported value '%s'", n.Value))
+	default:
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleAPIVersion, "apiVersion '%s' is not valid for kind '%s' (expected %s)", n.Value, kind, strings.Join(allowed, ", ")))
+	}
+	return errorsFound
+}
+
+// ---------- Metadata ----------
+
+func (v *Validator) traverseMetadata(filename string, meta *yaml.Node) []Finding {
+	var errorsFound []Finding
+	m := nodeMap(meta)
+	errorsFound = append(errorsFound, v.traverseUnknownKeys(filename, meta, "metadata", "metadata.")...)
+
+	// name / generateName: достаточно одного из двух
+	nameNode, hasName := m["name"]
+	hasName = hasName && nameNode.Value != ""
+	genNode, hasGen := m["generateName"]
+	hasGen = hasGen && genNode.Value != ""
+	if !hasName && !hasGen {
+		errorsFound = append(errorsFound, newFinding(filename, meta, ruleRequired, "metadata.name is required (or metadata.generateName)"))
+	}
+	if hasName && !validDNS1123Subdomain(nameNode.Value) {
+		errorsFound = append(errorsFound, newFinding(filename, nameNode, ruleObjectName, "metadata.name has invalid format '%s' (must be a DNS-1123 subdomain: lowercase alphanumerics, '-' or '.', at most 253 characters, starting and ending with an alphanumeric)", nameNode.Value))
+	}
+	if hasGen && !validGenerateName(genNode.Value) {
+		errorsFound = append(errorsFound, newFinding(filename, genNode, ruleObjectName, "metadata.generateName has invalid format '%s' (must be a DNS-1123 subdomain prefix, may end with '-')", genNode.Value))
+	}
+
+	// labels (необязательные)
+	if labelsNode, ok := m["labels"]; ok {
+		errorsFound = append(errorsFound, v.traverseLabels(filename, labelsNode, "metadata.labels")...)
+	}
+
+	// annotations (необязательные) — ключи по правилам меток, значения любые
+	if annNode, ok := m["annotations"]; ok {
+		if annNode.Kind != yaml.MappingNode {
+			errorsFound = append(errorsFound, newFinding(filename, annNode, ruleType, "metadata.annotations must be a mapping"))
+		} else {
+			for i := 0; i+1 < len(annNode.Content); i += 2 {
+				keyNode := annNode.Content[i]
+				if !validLabelKey(keyNode.Value) {
+					errorsFound = append(errorsFound, newFinding(filename, keyNode, ruleAnnotation, "invalid annotation key '%s'", keyNode.Value))
+				}
+			}
+		}
+	}
+
+	// namespace (необязательный)
+	if n, ok := m["namespace"]; ok && !validDNS1123Label(n.Value) {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleNamespace, "metadata.namespace has invalid format '%s' (must be a DNS-1123 label of at most 63 characters)", n.Value))
+	}
+
+	return errorsFound
+}
+
+func (v *Validator) traverseLabels(filename string, labels *yaml.Node, path string) []Finding {
+	var errorsFound []Finding
+	if isNull(labels) {
+		errorsFound = append(errorsFound, newFinding(filename, labels, ruleNull, "%s must not be null", path))
+		return errorsFound
+	}
+	if labels.Kind != yaml.MappingNode {
+		errorsFound = append(errorsFound, newFinding(filename, labels, ruleType, "%s must be a mapping", path))
+		return errorsFound
+	}
+
+	for i := 0; i+1 < len(labels.Content); i += 2 {
+		keyNode, valueNode := labels.Content[i], labels.Content[i+1]
+		if !validLabelKey(keyNode.Value) {
+			errorsFound = append(errorsFound, newFinding(filename, keyNode, ruleLabel, "%s has invalid key '%s'", path, keyNode.Value))
+		}
+		if !validLabelValue(valueNode.Value) {
+			errorsFound = append(errorsFound, newFinding(filename, valueNode, ruleLabel, "%s.%s has invalid value '%s'", path, keyNode.Value, valueNode.Value))
+		}
+	}
+
+	return errorsFound
+}
+
+// ---------- Spec ----------
+
+var specBoolFields = []string{
+	"automountServiceAccountToken", "enableServiceLinks", "hostIPC", "hostNetwork",
+	"hostPID", "hostUsers", "setHostnameAsFQDN", "shareProcessNamespace",
+}
+
+var dnsPolicies = map[string]bool{"ClusterFirst": true, "ClusterFirstWithHostNet": true, "Default": true, "None": true}
+
+var restartPolicies = map[string]bool{"Always": true, "OnFailure": true, "Never": true}
+
+// podPath — пути, от которых строятся сообщения о pod spec. У Pod они
+// короткие, как исторически сложилось ("spec.containers", "containers.name",
+// "containerPort"); в шаблоне пода Deployment, Job или CronJob путь
+// начинается от корня документа.
+type podPath struct {
+	spec      string // pod spec: "spec"
+	field     string // префикс полей внутри spec: "" у Pod
+	container string // список контейнеров: "containers"
+	inner     string // префикс полей внутри контейнера: "" у Pod
+}
+
+// podPaths возвращает пути для pod spec шаблона tmpl; "" — сам Pod.
+func podPaths(tmpl string) podPath {
+	if tmpl == "" {
+		return podPath{spec: "spec", container: "containers"}
+	}
+	spec := tmpl + ".spec"
+	return podPath{spec: spec, field: spec + ".", container: spec + ".containers", inner: spec + ".containers."}
+}
+
+// initContainers возвращает пути для spec.initContainers.
+func (p podPath) initContainers() podPath {
+	p.container = p.field + "initContainers"
+	if p.inner != "" {
+		p.inner = p.container + "."
+	}
+	return p
+}
+
+// under возвращает префикс полей внутри field контейнера: у Pod пустой
+// ("limits.cpu"), в шаблоне — полный путь.
+func (p podPath) under(field string) string {
+	if p.inner == "" {
+		return ""
+	}
+	return p.inner + field + "."
+}
+
+// at возвращает префикс "<path>: " для сообщений без пути; у Pod такие
+// сообщения остаются без префикса.
+func (p podPath) at(path string) string {
+	if p.field == "" {
+		return ""
+	}
+	return path + ": "
+}
+
+func (v *Validator) traverseSpec(filename string, spec *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	m := nodeMap(spec)
+	errorsFound = append(errorsFound, v.traverseUnknownKeys(filename, spec, "spec", p.spec+".")...)
+
+	// os (необязательный)
+	if osNode, ok := m["os"]; ok {
+		errorsFound = append(errorsFound, v.traverseOS(filename, spec, osNode, p)...)
+	}
+
+	// restartPolicy (необязательное, по умолчанию Always)
+	if n, ok := m["restartPolicy"]; ok && (n.Kind != yaml.ScalarNode || !restartPolicies[n.Value]) {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleRestartPolicy, "%s.restartPolicy has unsupported value '%s'", p.spec, n.Value))
+	}
+
+	// Логические поля: строка "true" вместо true ведёт себя неожиданно
+	for _, field := range specBoolFields {
+		if n, ok := m[field]; ok && !isBool(n) {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.%s must be a boolean", p.spec, field))
+		}
+	}
+
+	// terminationGracePeriodSeconds (необязательное)
+	if n, ok := m["terminationGracePeriodSeconds"]; ok {
+		if val, err := strconv.ParseInt(n.Value, 10, 64); n.Kind != yaml.ScalarNode || err != nil || val < 0 {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.terminationGracePeriodSeconds must be a non-negative integer", p.spec))
+		}
+	}
+
+	// priorityClassName (необязательное)
+	if n, ok := m["priorityClassName"]; ok && !validDNS1123Subdomain(n.Value) {
+		errorsFound = append(errorsFound, newFinding(filename, n, rulePriority, "%s.priorityClassName has invalid format '%s' (must be a DNS-1123 subdomain)", p.spec, n.Value))
+	}
+
+	// priority заполняет admission controller по priorityClassName
+	if n, ok := m["priority"]; ok {
+		if _, err := strconv.ParseInt(n.Value, 10, 32); err != nil {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.priority must be int", p.spec))
+		} else {
+			errorsFound = append(errorsFound, newWarning(filename, n, rulePriority, "%s.priority is set by the system and should not be set manually, use priorityClassName", p.spec))
+		}
+	}
+
+	// dnsPolicy (необязательная, по умолчанию ClusterFirst); для None
+	// серверы имён задаются только через dnsConfig
+	if n, ok := m["dnsPolicy"]; ok {
+		if !dnsPolicies[n.Value] {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleDNSPolicy, "%s.dnsPolicy has unsupported value '%s'", p.spec, n.Value))
+		} else if n.Value == "None" {
+			var ns *yaml.Node
+			if cfg, ok := m["dnsConfig"]; ok {
+				ns = nodeMap(cfg)["nameservers"]
+			}
+			if ns == nil || ns.Kind != yaml.SequenceNode || len(ns.Content) == 0 {
+				errorsFound = append(errorsFound, newFinding(filename, n, ruleDNSPolicy, "%s.dnsConfig.nameservers must contain at least one nameserver when dnsPolicy is None", p.spec))
+			}
+		}
+	}
+
+	// serviceAccountName (необязательное)
+	if n, ok := m["serviceAccountName"]; ok && !validDNS1123Subdomain(n.Value) {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleServiceAccount, "%s.serviceAccountName has invalid format '%s' (must be a DNS-1123 subdomain)", p.spec, n.Value))
+	}
+
+	// nodeSelector (необязательный) — ключи и значения по правилам меток
+	if selNode, ok := m["nodeSelector"]; ok {
+		errorsFound = append(errorsFound, v.traverseLabels(filename, selNode, p.spec+".nodeSelector")...)
+	}
+
+	// affinity (необязательная)
+	if affNode, ok := m["affinity"]; ok {
+		errorsFound = append(errorsFound, v.traverseAffinity(filename, affNode, p)...)
+	}
+
+	// tolerations (необязательные)
+	if tolNode, ok := m["tolerations"]; ok {
+		errorsFound = append(errorsFound, v.traverseTolerations(filename, tolNode, p)...)
+	}
+
+	// imagePullSecrets (необязательные) — ссылки-объекты, а не строки
+	if secretsNode, ok := m["imagePullSecrets"]; ok {
+		if secretsNode.Kind != yaml.SequenceNode {
+			errorsFound = append(errorsFound, newFinding(filename, secretsNode, ruleType, "%s.imagePullSecrets must be a list", p.spec))
+		} else {
+			for _, ref := range secretsNode.Content {
+				if ref.Kind != yaml.MappingNode {
+					errorsFound = append(errorsFound, newFinding(filename, ref, ruleType, "%s.imagePullSecrets entries must be mappings with a name", p.spec))
+				} else if n, ok := nodeMap(ref)["name"]; !ok || n.Value == "" {
+					errorsFound = append(errorsFound, newFinding(filename, ref, ruleRequired, "%s.imagePullSecrets.name is required", p.spec))
+				}
+			}
+		}
+	}
+
+	// hostAliases (необязательные)
+	if aliasesNode, ok := m["hostAliases"]; ok {
+		errorsFound = append(errorsFound, v.traverseHostAliases(filename, aliasesNode, p)...)
+	}
+
+	// volumes (необязательные)
+	if volNode, ok := m["volumes"]; ok {
+		errorsFound = append(errorsFound, v.traverseVolumes(filename, volNode, p)...)
+	}
+
+	// containers
+	contNode, ok := m["containers"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, spec, ruleRequired, "%s.containers is required", p.spec))
+		return errorsFound
+	}
+	if isNull(contNode) {
+		errorsFound = append(errorsFound, newFinding(filename, contNode, ruleNull, "%s.containers must not be null", p.spec))
+		return errorsFound
+	}
+	// Частая ошибка — контейнер без маркера "-" списка
+	if contNode.Kind == yaml.MappingNode {
+		errorsFound = append(errorsFound, newFinding(filename, contNode, ruleType, "%s.containers looks like a mapping; did you forget the '-' list markers?", p.spec))
+		return errorsFound
+	}
+	if contNode.Kind != yaml.SequenceNode {
+		errorsFound = append(errorsFound, newFinding(filename, contNode, ruleType, "%s.containers must be a list", p.spec))
+		return errorsFound
+	}
+	if len(contNode.Content) == 0 {
+		errorsFound = append(errorsFound, newFinding(filename, contNode, ruleRequired, "%s.containers must contain at least one container", p.spec))
+	}
+
+	volumes := volumeNames(m)
+	for _, c := range contNode.Content {
+		errorsFound = append(errorsFound, v.traverseContainer(filename, c, volumes, p)...)
+		if v.WarnMissingProbes {
+			cm := nodeMap(c)
+			if presentKeys(cm, "readinessProbe", "livenessProbe") == nil {
+				name := ""
+				if n, ok := cm["name"]; ok {
+					name = n.Value
+				}
+				errorsFound = append(errorsFound, newWarning(filename, c, ruleMissingProbe, "%scontainer '%s' has neither readinessProbe nor livenessProbe", p.at(p.container), name))
+			}
+		}
+	}
+
+	// initContainers (необязательные) — та же схема, что у containers
+	allContainers := contNode.Content
+	if initNode, ok := m["initContainers"]; ok {
+		if isNull(initNode) {
+			errorsFound = append(errorsFound, newFinding(filename, initNode, ruleNull, "%s.initContainers must not be null", p.spec))
+		} else if initNode.Kind == yaml.MappingNode {
+			errorsFound = append(errorsFound, newFinding(filename, initNode, ruleType, "%s.initContainers looks like a mapping; did you forget the '-' list markers?", p.spec))
+		} else if initNode.Kind != yaml.SequenceNode {
+			errorsFound = append(errorsFound, newFinding(filename, initNode, ruleType, "%s.initContainers must be a list", p.spec))
+		} else {
+			for _, c := range initNode.Content {
+				errorsFound = append(errorsFound, v.traverseInitContainer(filename, c, volumes, p.initContainers())...)
+			}
+			allContainers = append(append([]*yaml.Node{}, initNode.Content...), contNode.Content...)
+		}
+	}
+
+	errorsFound = append(errorsFound, v.traverseDuplicateNames(filename, allContainers, p)...)
+	errorsFound = append(errorsFound, v.traverseDuplicatePorts(filename, contNode.Content, p)...)
+	errorsFound = append(errorsFound, v.traverseRunAsNonRoot(filename, spec, contNode.Content, p)...)
+
+	return errorsFound
+}
+
+// ---------- OS ----------
+
+// windowsForbiddenFields — поля pod spec и его securityContext, которые
+// Kubernetes не поддерживает для Windows.
+var (
+	windowsForbiddenFields         = []string{"hostPID", "hostIPC", "shareProcessNamespace"}
+	windowsForbiddenSecurityFields = []string{
+		"seLinuxOptions", "seccompProfile", "fsGroup", "fsGroupChangePolicy",
+		"sysctls", "runAsUser", "runAsGroup", "supplementalGroups",
+	}
+)
+
+// traverseOS проверяет spec.os: по схеме Kubernetes это mapping с полем
+// name, но ранее поддерживаемая скалярная форма пока допускается.
+func (v *Validator) traverseOS(filename string, spec, osNode *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+
+	nameNode, path := osNode, p.spec+".os"
+	switch osNode.Kind {
+	case yaml.ScalarNode:
+		errorsFound = append(errorsFound, newWarning(filename, osNode, ruleOS, "%s as a string is deprecated, use 'os: {name: %s}'", path, osNode.Value))
+	case yaml.MappingNode:
+		n, ok := nodeMap(osNode)["name"]
+		if !ok || n.Value == "" {
+			errorsFound = append(errorsFound, newFinding(filename, osNode, ruleRequired, "%s.name is required", path))
+			return errorsFound
+		}
+		nameNode, path = n, path+".name"
+	default:
+		errorsFound = append(errorsFound, newFinding(filename, osNode, ruleType, "%s must be a mapping", path))
+		return errorsFound
+	}
+
+	if !v.rules().allowedOS(nameNode.Value) {
+		errorsFound = append(errorsFound, newFinding(filename, nameNode, ruleOS, "%s has unsupported value '%s'", path, nameNode.Value))
+	}
+	if nameNode.Value != "windows" {
+		return errorsFound
+	}
+
+	m := nodeMap(spec)
+	for _, field := range windowsForbiddenFields {
+		if n, ok := m[field]; ok {
+			errorsFound = append(errorsFound, newWarning(filename, n, ruleOS, "%s.%s is not supported for windows pods", p.spec, field))
+		}
+	}
+	if sc, ok := m["securityContext"]; ok {
+		scm := nodeMap(sc)
+		for _, field := range windowsForbiddenSecurityFields {
+			if n, ok := scm[field]; ok {
+				errorsFound = append(errorsFound, newWarning(filename, n, ruleOS, "%s.securityContext.%s is not supported for windows pods", p.spec, field))
+			}
+		}
+	}
+	return errorsFound
+}
+
+// ---------- Container ----------
+
+// traverseDuplicateNames сообщает о повторных именах контейнеров.
+// Пустые имена пропускаются: о них уже сообщает traverseContainer.
+func (v *Validator) traverseDuplicateNames(filename string, containers []*yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	seen := map[string]bool{}
+
+	for _, c := range containers {
+		n, ok := nodeMap(c)["name"]
+		if !ok || n.Value == "" {
+			continue
+		}
+		if seen[n.Value] {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleDuplicateName, "%sduplicate container name '%s'", p.at(p.spec), n.Value))
+		}
+		seen[n.Value] = true
+	}
+
+	return errorsFound
+}
+
+var containerBoolFields = []string{"stdin", "stdinOnce", "tty"}
+
+var imagePullPolicies = map[string]bool{"Always": true, "IfNotPresent": true, "Never": true}
+
+func (v *Validator) traverseContainer(filename string, c *yaml.Node, volumes map[string]bool, p podPath) []Finding {
+	var errorsFound []Finding
+	m := nodeMap(c)
+	errorsFound = append(errorsFound, v.traverseUnknownKeys(filename, c, "container", p.container+".")...)
+
+	// name
+	if n, ok := m["name"]; !ok || n.Value == "" {
+		errorsFound = append(errorsFound, newFinding(filename, c, ruleRequired, "%s.name is required", p.container))
+	} else {
+		if !v.rules().containerNameRe.MatchString(n.Value) {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleContainerName, "%s.name has invalid format '%s'", p.container, n.Value))
+		}
+	}
+
+	// image
+	if n, ok := m["image"]; !ok || n.Value == "" {
+		errorsFound = append(errorsFound, newFinding(filename, c, ruleRequired, "%s.image is required", p.container))
+	} else {
+		if registries := v.rules().Registries; !hasAnyPrefix(n.Value, registries) {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleImageRegistry, "%s.image has unsupported value '%s' (allowed registries: %s)", p.container, n.Value, strings.Join(registries, ", ")))
+		}
+		ref := parseImage(n.Value)
+		switch {
+		case ref.digest != "" && !digestRe.MatchString(ref.digest):
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleImageDigest, "%s.image has invalid digest '%s'", p.container, ref.digest))
+		case ref.tag == "" && ref.digest == "":
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleImageTag, "%s.image must include tag", p.container))
+		case ref.tag == "latest" && v.DisallowLatest:
+			// То же правило, что и предупреждение ниже, но ошибкой
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleImageLatest, "%s.image must not use tag 'latest'", p.container))
+		case ref.tag == "latest" && ref.digest == "":
+			// latest мешает воспроизводимым выкладкам; образ с дайджестом закреплён
+			errorsFound = append(errorsFound, newWarning(filename, n, ruleImageLatest, "%s.image uses tag 'latest'", p.container))
+		}
+	}
+
+	for _, field := range containerBoolFields {
+		if n, ok := m[field]; ok && !isBool(n) {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.%s must be a boolean", p.container, field))
+		}
+	}
+
+	// command и args (необязательные) — только списки строк; строка
+	// вместо списка меняет смысл точки входа
+	for _, field := range []string{"command", "args"} {
+		n, ok := m[field]
+		if !ok {
+			continue
+		}
+		if n.Kind != yaml.SequenceNode {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.%s must be a list of strings", p.container, field))
+			continue
+		}
+		for _, item := range n.Content {
+			if item.Kind != yaml.ScalarNode {
+				errorsFound = append(errorsFound, newFinding(filename, item, ruleType, "%s.%s must be a list of strings", p.container, field))
+			}
+		}
+	}
+
+	// workingDir (необязательный)
+	if n, ok := m["workingDir"]; ok && (n.Kind != yaml.ScalarNode || !strings.HasPrefix(n.Value, "/")) {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleWorkingDir, "%s.workingDir must be an absolute path", p.container))
+	}
+
+	// imagePullPolicy (необязательный)
+	if n, ok := m["imagePullPolicy"]; ok {
+		if !imagePullPolicies[n.Value] {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleImagePullPolicy, "%s.imagePullPolicy has unsupported value '%s'", p.container, n.Value))
+		} else if img, ok := m["image"]; ok && parseImage(img.Value).tag == "latest" && n.Value != "Always" {
+			// Без Always узел будет запускать закэшированный latest
+			errorsFound = append(errorsFound, newWarning(filename, n, ruleImagePullPolicy, "%s.imagePullPolicy should be Always for images tagged 'latest'", p.container))
+		}
+	}
+
+	// ports (необязательные)
+	if portsNode, ok := m["ports"]; ok && portsNode.Kind == yaml.SequenceNode {
+		seen := map[string]bool{}
+		for _, port := range portsNode.Content {
+			errorsFound = append(errorsFound, v.traversePort(filename, port, p)...)
+			// Имена портов должны быть уникальны в пределах контейнера
+			if n, ok := nodeMap(port)["name"]; ok && n.Value != "" {
+				if seen[n.Value] {
+					errorsFound = append(errorsFound, newFinding(filename, n, rulePortName, "%sduplicate port name '%s'", p.at(p.container+".ports"), n.Value))
+				}
+				seen[n.Value] = true
+			}
+		}
+	}
+
+	// env (необязательные)
+	if envNode, ok := m["env"]; ok {
+		errorsFound = append(errorsFound, v.traverseEnv(filename, envNode, p)...)
+	}
+
+	// envFrom (необязательные)
+	if envFromNode, ok := m["envFrom"]; ok {
+		errorsFound = append(errorsFound, v.traverseEnvFrom(filename, envFromNode, p)...)
+	}
+
+	// volumeMounts (необязательные)
+	if mountsNode, ok := m["volumeMounts"]; ok {
+		errorsFound = append(errorsFound, v.traverseVolumeMounts(filename, mountsNode, volumes, p)...)
+	}
+
+	// Пробы могут ссылаться на порт по имени
+	ports := portNames(m)
+
+	// readinessProbe
+	if rNode, ok := m["readinessProbe"]; ok {
+		errorsFound = append(errorsFound, v.traverseProbe(filename, rNode, p.inner+"readinessProbe", ports)...)
+	}
+
+	// livenessProbe
+	if lNode, ok := m["livenessProbe"]; ok {
+		errorsFound = append(errorsFound, v.traverseProbe(filename, lNode, p.inner+"livenessProbe", ports)...)
+	}
+
+	// Одинаковые readiness и liveness перезапускают контейнер, как только
+	// он перестаёт принимать трафик под нагрузкой
+	if rNode, ok := m["readinessProbe"]; ok {
+		if lNode, ok := m["livenessProbe"]; ok && sameNode(rNode, lNode) {
+			name := "unnamed"
+			if n, ok := m["name"]; ok && n.Value != "" {
+				name = n.Value
+			}
+			errorsFound = append(errorsFound, newWarning(filename, c, ruleIdenticalProbes, "%scontainer '%s' has identical readinessProbe and livenessProbe", p.at(p.container), name))
+		}
+	}
+
+	// startupProbe
+	if sNode, ok := m["startupProbe"]; ok {
+		errorsFound = append(errorsFound, v.traverseProbe(filename, sNode, p.inner+"startupProbe", ports)...)
+	}
+
+	// securityContext (необязательный)
+	if scNode, ok := m["securityContext"]; ok {
+		errorsFound = append(errorsFound, v.traverseSecurityContext(filename, scNode, p)...)
+	}
+
+	// lifecycle (необязательный)
+	if lcNode, ok := m["lifecycle"]; ok {
+		errorsFound = append(errorsFound, v.traverseLifecycle(filename, lcNode, ports, p)...)
+	}
+
+	// resources
+	if resNode, ok := m["resources"]; ok {
+		errorsFound = append(errorsFound, v.traverseResources(filename, resNode, p)...)
+	} else {
+		errorsFound = append(errorsFound, newFinding(filename, c, ruleRequired, "%s.resources is required", p.container))
+	}
+
+	return errorsFound
+}
+
+var initContainerProbes = []string{"readinessProbe", "livenessProbe", "startupProbe"}
+
+// p — пути уже для spec.initContainers.
+func (v *Validator) traverseInitContainer(filename string, c *yaml.Node, volumes map[string]bool, p podPath) []Finding {
+	errorsFound := v.traverseContainer(filename, c, volumes, p)
+
+	// Init-контейнер завершается до старта пода, пробы для него бессмысленны
+	m := nodeMap(c)
+	for _, probe := range initContainerProbes {
+		if n, ok := m[probe]; ok {
+			errorsFound = append(errorsFound, newWarning(filename, n, ruleInitProbe, "%s.%s is not used for init containers", p.container, probe))
+		}
+	}
+
+	return errorsFound
+}
+
+// ---------- ContainerPort ----------
+
+// portNames возвращает имена портов контейнера.
+func portNames(container map[string]*yaml.Node) map[string]bool {
+	names := map[string]bool{}
+	if portsNode, ok := container["ports"]; ok && portsNode.Kind == yaml.SequenceNode {
+		for _, p := range portsNode.Content {
+			if n, ok := nodeMap(p)["name"]; ok && n.Value != "" {
+				names[n.Value] = true
+			}
+		}
+	}
+	return names
+}
+
+// traverseDuplicatePorts ищет одинаковые пары containerPort/protocol
+// во всех контейнерах пода.
+func (v *Validator) traverseDuplicatePorts(filename string, containers []*yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	seen := map[string]bool{}
+
+	for _, c := range containers {
+		portsNode, ok := nodeMap(c)["ports"]
+		if !ok || portsNode.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, port := range portsNode.Content {
+			pm := nodeMap(port)
+			n, ok := pm["containerPort"]
+			if !ok {
+				continue
+			}
+			// Kubernetes подставляет TCP, если протокол не указан
+			protocol := "TCP"
+			if pn, ok := pm["protocol"]; ok {
+				protocol = pn.Value
+			}
+			key := n.Value + "/" + protocol
+			if seen[key] {
+				errorsFound = append(errorsFound, newFinding(filename, n, ruleDuplicatePort, "%sduplicate containerPort %s", p.at(p.container), key))
+			}
+			seen[key] = true
+		}
+	}
+
+	return errorsFound
+}
+
+func (v *Validator) traversePort(filename string, port *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	// ports: [8080] — список чисел вместо списка портов
+	if port.Kind != yaml.MappingNode {
+		errorsFound = append(errorsFound, newFinding(filename, port, ruleType, "%sport entry must be a mapping", p.at(p.container+".ports")))
+		return errorsFound
+	}
+	m := nodeMap(port)
+	errorsFound = append(errorsFound, v.traverseUnknownKeys(filename, port, "port", p.inner+"ports.")...)
+	path := p.under("ports")
+
+	// containerPort
+	if n, ok := m["containerPort"]; !ok {
+		errorsFound = append(errorsFound, newFinding(filename, port, ruleRequired, "%scontainerPort is required", path))
+	} else {
+		if _, err := strconv.Atoi(n.Value); err != nil {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%scontainerPort must be int", path))
+		} else if port, _ := strconv.Atoi(n.Value); port <= 0 || port >= 65536 {
+			errorsFound = append(errorsFound, newFinding(filename, n, rulePortRange, "%scontainerPort value out of range", path))
+		}
+	}
+
+	// name (необязательное)
+	if n, ok := m["name"]; ok && !validPortName(n.Value) {
+		errorsFound = append(errorsFound, newFinding(filename, n, rulePortName, "%sports.name has invalid format '%s' (must be a lowercase DNS-1123 label of at most 15 characters with at least one letter)", p.inner, n.Value))
+	}
+
+	// hostPort (необязательный)
+	if n, ok := m["hostPort"]; ok {
+		if hostPort, err := strconv.Atoi(n.Value); err != nil {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%shostPort must be int", path))
+		} else if hostPort <= 0 || hostPort >= 65536 {
+			errorsFound = append(errorsFound, newFinding(filename, n, rulePortRange, "%shostPort value out of range", path))
+		} else if hostPort < 1024 && v.Strict {
+			// Привилегированные порты узла часто запрещены политиками кластера
+			errorsFound = append(errorsFound, newWarning(filename, n, ruleHostPortPrivilege, "%shostPort %d is a privileged port", path, hostPort))
+		}
+	}
+
+	// protocol
+	if n, ok := m["protocol"]; ok {
+		if n.Value != "TCP" && n.Value != "UDP" {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleProtocol, "%sprotocol has unsupported value '%s'", path, n.Value))
+		}
+	}
+
+	return errorsFound
+}
+
+// ---------- Resources ----------
+
+func (v *Validator) traverseResources(filename string, res *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	m := nodeMap(res)
+	errorsFound = append(errorsFound, v.traverseUnknownKeys(filename, res, "resources", p.inner+"resources.")...)
+	path := p.under("resources")
+
+	rules := v.rules()
+	for _, kind := range []string{"limits", "requests"} {
+		if node, ok := m[kind]; ok && node.Kind == yaml.MappingNode {
+			// Обходим пары в порядке документа, а не по map: иначе порядок
+			// находок меняется от запуска к запуску
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				k, n := node.Content[i].Value, node.Content[i+1]
+				switch k {
+				case "cpu":
+					if !rules.checkCPU(n.Value) {
+						errorsFound = append(errorsFound, newFinding(filename, n, ruleCPU, "%s%s.cpu has invalid format '%s'", path, kind, n.Value))
+					} else if !positiveQuantity(n.Value) {
+						errorsFound = append(errorsFound, newFinding(filename, n, ruleCPU, "%s%s.cpu must be greater than zero", path, kind))
+					}
+				case "memory", "ephemeral-storage":
+					if !rules.checkMem(n.Value) {
+						errorsFound = append(errorsFound, newFinding(filename, n, ruleMemory, "%s%s.%s has invalid format '%s'", path, kind, k, n.Value))
+					} else if k == "memory" && !positiveQuantity(n.Value) {
+						errorsFound = append(errorsFound, newFinding(filename, n, ruleMemory, "%s%s.memory must be greater than zero", path, kind))
+					}
+				default:
+					errorsFound = append(errorsFound, v.traverseExtendedResource(filename, path+kind, k, n)...)
+				}
+			}
+		}
+	}
+
+	errorsFound = append(errorsFound, v.traverseRequestsLimits(filename, m["requests"], m["limits"], path)...)
+
+	// Для квот нужны и запросы, и лимиты
+	if v.RequireLimits {
+		for _, kind := range []string{"requests", "limits"} {
+			var km map[string]*yaml.Node
+			if node, ok := m[kind]; ok {
+				km = nodeMap(node)
+			}
+			for _, name := range []string{"cpu", "memory"} {
+				if _, ok := km[name]; !ok {
+					errorsFound = append(errorsFound, newFinding(filename, res, ruleRequired, "%s.resources.%s.%s is required", p.container, kind, name))
+				}
+			}
+		}
+	}
+
+	return errorsFound
+}
+
+var wholeQuantityRe = regexp.MustCompile(`^[0-9]+$`)
+
+// traverseExtendedResource проверяет ресурсы, кроме cpu, memory и
+// ephemeral-storage: hugepages-<size> в формате памяти и расширенные
+// ресурсы вида <domain>/<name> с целым количеством. kind — путь к
+// limits или requests.
+func (v *Validator) traverseExtendedResource(filename, kind, name string, n *yaml.Node) []Finding {
+	var errorsFound []Finding
+
+	switch {
+	case strings.HasPrefix(name, "hugepages-"):
+		if !v.rules().checkMem(n.Value) {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleMemory, "%s.%s has invalid format '%s'", kind, name, n.Value))
+		}
+	case strings.Contains(name, "/"):
+		if !validLabelKey(name) {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleExtendedResource, "%s has invalid resource name '%s' (must be <domain>/<name>)", kind, name))
+		}
+		if !wholeQuantityRe.MatchString(n.Value) {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleExtendedResource, "%s.%s must be a whole number", kind, name))
+		}
+	case v.Strict:
+		errorsFound = append(errorsFound, newWarning(filename, n, ruleUnknownResource, "unknown resource '%s.%s'", kind, name))
+	}
+
+	return errorsFound
+}
+
+// traverseRequestsLimits сообщает о запросах, превышающих лимит того же
+// ресурса: такой контейнер Kubernetes не примет. path — префикс
+// resources в сообщениях.
+func (v *Validator) traverseRequestsLimits(filename string, requests, limits *yaml.Node, path string) []Finding {
+	var errorsFound []Finding
+	if requests == nil || limits == nil || requests.Kind != yaml.MappingNode {
+		return errorsFound
+	}
+
+	lm := nodeMap(limits)
+	for i := 0; i+1 < len(requests.Content); i += 2 {
+		k, n := requests.Content[i].Value, requests.Content[i+1]
+		ln, ok := lm[k]
+		if !ok {
+			continue
+		}
+		// Значения неверного формата уже сообщены выше
+		req, ok := parseQuantity(n.Value)
+		if !ok {
+			continue
+		}
+		lim, ok := parseQuantity(ln.Value)
+		if !ok {
+			continue
+		}
+		if req.Cmp(lim) > 0 {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleRequestsLimits, "%srequests.%s exceeds limits.%s", path, k, k))
+		}
+	}
+
+	return errorsFound
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEmptyContainers(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  containers: []
+`
+	findings := validate(t, manifest)
+	want := []string{"spec.containers must contain at least one container"}
+	if got := messages(findings); !reflect.DeepEqual(got, want) {
+		t.Fatalf("messages = %q, want %q", got, want)
+	}
+	if f := findings[0]; f.Line != 6 || f.Rule != ruleRequired {
+		t.Errorf("finding = %+v, want line 6, rule %s", f, ruleRequired)
+	}
+}
+
+func TestMetadataGenerateName(t *testing.T) {
+	tests := []struct {
+		name string
+		meta string
+		want []string
+	}{
+		{"generateName only", "generateName: web-", []string{}},
+		{"name only", "name: web", []string{}},
+		{"neither", "labels: {app: web}", []string{"metadata.name is required (or metadata.generateName)"}},
+		{"invalid generateName", "generateName: Web_", []string{"metadata.generateName has invalid format 'Web_' (must be a DNS-1123 subdomain prefix, may end with '-')"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := strings.Replace(podManifest, "  name: web", "  "+tt.meta, 1)
+			if got := messages(validate(t, manifest)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     []string
+	}{
+		{"pod", strings.Replace(podManifest, "apiVersion: v1", "apiVersion: apps/v1", 1), []string{"apiVersion has unsupported value 'apps/v1'"}},
+		{"deployment", "apiVersion: v1\nkind: Deployment\nmetadata:\n  name: web\n", []string{"apiVersion 'v1' is not valid for kind 'Deployment' (expected apps/v1)", "spec is required"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := messages(validate(t, tt.manifest)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisallowLatest(t *testing.T) {
+	manifest := strings.Replace(podManifest, "app:1.0", "app:latest", 1)
+	tests := []struct {
+		name      string
+		v         *Validator
+		wantRules []string
+		wantError bool
+	}{
+		{"default", &Validator{}, []string{ruleImageLatest}, false},
+		{"disallowed", &Validator{DisallowLatest: true}, []string{ruleImageLatest}, true},
+		{"disabled", &Validator{DisallowLatest: true, Rules: &Rules{DisabledRules: []string{ruleImageLatest}}}, []string{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := tt.v.Validate("pod.yaml", []byte(manifest))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rules := []string{}
+			for _, f := range findings {
+				rules = append(rules, f.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.wantRules) {
+				t.Fatalf("rules = %q, want %q", rules, tt.wantRules)
+			}
+			if got := NewResult(findings).HasErrors(); got != tt.wantError {
+				t.Errorf("HasErrors = %v, want %v", got, tt.wantError)
+			}
+		})
+	}
+}
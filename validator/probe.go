@@ -0,0 +1,206 @@
+package validator
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- Probe ----------
+
+var probeHandlers = []string{"httpGet", "exec", "tcpSocket", "grpc"}
+
+var probeTimings = []string{"initialDelaySeconds", "periodSeconds", "timeoutSeconds", "successThreshold", "failureThreshold"}
+
+// ports — имена портов контейнера, на которые может ссылаться проба.
+func (v *Validator) traverseProbe(filename string, probe *yaml.Node, name string, ports map[string]bool) []Finding {
+	var errorsFound []Finding
+	errorsFound = append(errorsFound, v.traverseUnknownKeys(filename, probe, "probe", name+".")...)
+	errorsFound = append(errorsFound, v.traverseHandler(filename, probe, name, probeHandlers, ports)...)
+
+	m := nodeMap(probe)
+	for _, field := range probeTimings {
+		n, ok := m[field]
+		if !ok {
+			continue
+		}
+		if val, err := strconv.Atoi(n.Value); err != nil || val < 0 {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleProbeTiming, "%s.%s must be a non-negative int", name, field))
+		}
+	}
+
+	// Kubernetes допускает для livenessProbe и startupProbe только successThreshold: 1
+	if n, ok := m["successThreshold"]; ok && name != "readinessProbe" && n.Value != "1" {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleProbeTiming, "%s.successThreshold must be 1", name))
+	}
+
+	return errorsFound
+}
+
+// traverseHandler проверяет, что в node задан ровно один обработчик из
+// allowed, и проверяет его поля.
+func (v *Validator) traverseHandler(filename string, node *yaml.Node, name string, allowed []string, ports map[string]bool) []Finding {
+	var errorsFound []Finding
+	m := nodeMap(node)
+
+	handlers := presentKeys(m, allowed...)
+	switch {
+	case len(handlers) == 0:
+		errorsFound = append(errorsFound, newFinding(filename, node, ruleRequired, "%s handler is required (one of %s)", name, strings.Join(allowed, ", ")))
+		return errorsFound
+	case len(handlers) > 1:
+		// Kubernetes выберет один из обработчиков произвольно
+		errorsFound = append(errorsFound, newFinding(filename, m[handlers[1]], ruleProbeHandler, "%s must specify exactly one of %s", name, strings.Join(allowed, ", ")))
+		return errorsFound
+	}
+
+	switch handlers[0] {
+	case "httpGet":
+		errorsFound = append(errorsFound, v.traverseHTTPGet(filename, m["httpGet"], name, ports)...)
+	case "exec":
+		errorsFound = append(errorsFound, v.traverseExec(filename, m["exec"], name)...)
+	case "tcpSocket":
+		errorsFound = append(errorsFound, v.traverseTCPSocket(filename, m["tcpSocket"], name, ports)...)
+	case "grpc":
+		errorsFound = append(errorsFound, v.traverseGRPC(filename, m["grpc"], name)...)
+	}
+	return errorsFound
+}
+
+func (v *Validator) traverseHTTPGet(filename string, httpNode *yaml.Node, name string, ports map[string]bool) []Finding {
+	var errorsFound []Finding
+	m2 := nodeMap(httpNode)
+	errorsFound = append(errorsFound, v.traverseUnknownKeys(filename, httpNode, "httpGet", name+".httpGet.")...)
+
+	// path
+	if n, ok := m2["path"]; !ok || n.Value == "" {
+		errorsFound = append(errorsFound, newFinding(filename, httpNode, ruleRequired, "%s.httpGet.path is required", name))
+	} else if !strings.HasPrefix(n.Value, "/") {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleProbePath, "%s.httpGet.path has invalid format '%s'", name, n.Value))
+	}
+
+	// port
+	errorsFound = append(errorsFound, checkHandlerPort(filename, httpNode, name+".httpGet", ports)...)
+
+	// scheme (необязательная, по умолчанию HTTP)
+	if n, ok := m2["scheme"]; ok && n.Value != "HTTP" && n.Value != "HTTPS" {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleProbeScheme, "%s.httpGet.scheme has unsupported value '%s'", name, n.Value))
+	}
+
+	// host (необязательный): по умолчанию IP пода, задавать его почти
+	// никогда не нужно
+	if n, ok := m2["host"]; ok {
+		if net.ParseIP(n.Value) == nil && !validDNS1123Subdomain(n.Value) {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleProbeHost, "%s.httpGet.host has invalid format '%s'", name, n.Value))
+		} else {
+			errorsFound = append(errorsFound, newWarning(filename, n, ruleProbeHost, "%s.httpGet.host is rarely needed; the pod IP is used by default", name))
+		}
+	}
+
+	// httpHeaders (необязательные)
+	if n, ok := m2["httpHeaders"]; ok {
+		if n.Kind != yaml.SequenceNode {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.httpGet.httpHeaders must be a list", name))
+		} else {
+			for _, h := range n.Content {
+				hm := nodeMap(h)
+				for _, field := range []string{"name", "value"} {
+					if fn, ok := hm[field]; !ok || fn.Value == "" {
+						errorsFound = append(errorsFound, newFinding(filename, h, ruleRequired, "%s.httpGet.httpHeaders.%s is required", name, field))
+					}
+				}
+			}
+		}
+	}
+
+	return errorsFound
+}
+
+func (v *Validator) traverseExec(filename string, execNode *yaml.Node, name string) []Finding {
+	var errorsFound []Finding
+
+	n, ok := nodeMap(execNode)["command"]
+	if !ok || n.Kind != yaml.SequenceNode || len(n.Content) == 0 {
+		errorsFound = append(errorsFound, newFinding(filename, execNode, ruleRequired, "%s.exec.command is required", name))
+		return errorsFound
+	}
+	for _, item := range n.Content {
+		if item.Kind != yaml.ScalarNode {
+			errorsFound = append(errorsFound, newFinding(filename, item, ruleType, "%s.exec.command must be a list of strings", name))
+		}
+	}
+
+	return errorsFound
+}
+
+func (v *Validator) traverseTCPSocket(filename string, tcpNode *yaml.Node, name string, ports map[string]bool) []Finding {
+	return checkHandlerPort(filename, tcpNode, name+".tcpSocket", ports)
+}
+
+// traverseGRPC проверяет grpc-пробу: port, в отличие от httpGet и
+// tcpSocket, задаётся только числом.
+func (v *Validator) traverseGRPC(filename string, grpcNode *yaml.Node, name string) []Finding {
+	var errorsFound []Finding
+	m := nodeMap(grpcNode)
+
+	if n, ok := m["port"]; !ok {
+		errorsFound = append(errorsFound, newFinding(filename, grpcNode, ruleRequired, "%s.grpc.port is required", name))
+	} else if port, err := strconv.Atoi(n.Value); err != nil || n.Kind != yaml.ScalarNode || n.Tag != "!!int" {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.grpc.port must be int", name))
+	} else if port <= 0 || port >= 65536 {
+		errorsFound = append(errorsFound, newFinding(filename, n, rulePortRange, "%s.grpc.port value out of range", name))
+	}
+
+	// service (необязательный) — имя сервиса для grpc.health.v1
+	if n, ok := m["service"]; ok && n.Kind != yaml.ScalarNode {
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.grpc.service must be a string", name))
+	}
+
+	return errorsFound
+}
+
+// checkHandlerPort проверяет обязательное поле port обработчика: номер
+// порта или имя одного из ports контейнера.
+func checkHandlerPort(filename string, handler *yaml.Node, path string, ports map[string]bool) []Finding {
+	var errorsFound []Finding
+
+	if n, ok := nodeMap(handler)["port"]; !ok {
+		errorsFound = append(errorsFound, newFinding(filename, handler, ruleRequired, "%s.port is required", path))
+	} else if port, err := strconv.Atoi(n.Value); err != nil {
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!str" {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.port must be int or string", path))
+		} else if !ports[n.Value] {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleProbePort, "%s.port refers to unknown container port '%s'", path, n.Value))
+		}
+	} else if port <= 0 || port >= 65536 {
+		errorsFound = append(errorsFound, newFinding(filename, n, rulePortRange, "%s.port value out of range", path))
+	}
+
+	return errorsFound
+}
+
+// ---------- Lifecycle ----------
+
+var lifecycleHooks = []string{"postStart", "preStop"}
+
+var lifecycleHandlers = []string{"httpGet", "exec", "tcpSocket"}
+
+// traverseLifecycle проверяет хуки контейнера: у каждого, как у пробы,
+// ровно один обработчик.
+func (v *Validator) traverseLifecycle(filename string, lifecycle *yaml.Node, ports map[string]bool, p podPath) []Finding {
+	var errorsFound []Finding
+	if lifecycle.Kind != yaml.MappingNode {
+		errorsFound = append(errorsFound, newFinding(filename, lifecycle, ruleType, "%s.lifecycle must be a mapping", p.container))
+		return errorsFound
+	}
+
+	m := nodeMap(lifecycle)
+	for _, hook := range lifecycleHooks {
+		if n, ok := m[hook]; ok {
+			errorsFound = append(errorsFound, v.traverseHandler(filename, n, p.inner+"lifecycle."+hook, lifecycleHandlers, ports)...)
+		}
+	}
+	return errorsFound
+}
@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProbeHandlers(t *testing.T) {
+	tests := []struct {
+		name  string
+		probe string
+		want  []string
+	}{
+		{
+			name:  "none",
+			probe: "{periodSeconds: 5}",
+			want:  []string{"readinessProbe handler is required (one of httpGet, exec, tcpSocket, grpc)"},
+		},
+		{
+			name:  "one",
+			probe: "{tcpSocket: {port: 8080}}",
+			want:  []string{},
+		},
+		{
+			name:  "grpc",
+			probe: "{grpc: {port: 9090}}",
+			want:  []string{},
+		},
+		{
+			name:  "grpc named port",
+			probe: "{grpc: {port: http}}",
+			want:  []string{"readinessProbe.grpc.port must be int"},
+		},
+		{
+			name:  "grpc port out of range",
+			probe: "{grpc: {port: 70000}}",
+			want:  []string{"readinessProbe.grpc.port value out of range"},
+		},
+		{
+			name:  "two",
+			probe: "{httpGet: {path: /healthz, port: 8080}, tcpSocket: {port: 8080}}",
+			want:  []string{"readinessProbe must specify exactly one of httpGet, exec, tcpSocket, grpc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := strings.Replace(podManifest, "      resources:", "      readinessProbe: "+tt.probe+"\n      resources:", 1)
+			if got := messages(validate(t, manifest)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestIdenticalProbes проверяет и копию пробы через якорь YAML.
+func TestIdenticalProbes(t *testing.T) {
+	tests := []struct {
+		name   string
+		probes string
+		want   []string
+	}{
+		{
+			name:   "copy",
+			probes: "readinessProbe: {tcpSocket: {port: 8080}}\n      livenessProbe: {tcpSocket: {port: 8080}}",
+			want:   []string{"container 'app' has identical readinessProbe and livenessProbe"},
+		},
+		{
+			name:   "anchor",
+			probes: "readinessProbe: &probe {tcpSocket: {port: 8080}}\n      livenessProbe: *probe",
+			want:   []string{"container 'app' has identical readinessProbe and livenessProbe"},
+		},
+		{
+			name:   "different",
+			probes: "readinessProbe: {tcpSocket: {port: 8080}}\n      livenessProbe: {tcpSocket: {port: 8081}}",
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := strings.Replace(podManifest, "      resources:", "      "+tt.probes+"\n      resources:", 1)
+			if got := messages(validate(t, manifest)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"math/big"
+	"regexp"
+)
+
+// ---------- Quantity ----------
+
+var quantityRe = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?|\.[0-9]+)(m|[kMGTPE]|[KMGTPE]i)?$`)
+
+// quantitySuffixes — множители суффиксов количеств Kubernetes.
+var quantitySuffixes = map[string]*big.Rat{
+	"":   big.NewRat(1, 1),
+	"m":  big.NewRat(1, 1000),
+	"k":  ratPow(1000, 1),
+	"M":  ratPow(1000, 2),
+	"G":  ratPow(1000, 3),
+	"T":  ratPow(1000, 4),
+	"P":  ratPow(1000, 5),
+	"E":  ratPow(1000, 6),
+	"Ki": ratPow(1024, 1),
+	"Mi": ratPow(1024, 2),
+	"Gi": ratPow(1024, 3),
+	"Ti": ratPow(1024, 4),
+	"Pi": ratPow(1024, 5),
+	"Ei": ratPow(1024, 6),
+}
+
+func ratPow(base, exp int64) *big.Rat {
+	n := new(big.Int).Exp(big.NewInt(base), big.NewInt(exp), nil)
+	return new(big.Rat).SetInt(n)
+}
+
+// parseQuantity приводит количество вроде "500m" или "1Gi" к точному
+// числу, чтобы значения с разными суффиксами можно было сравнивать.
+func parseQuantity(s string) (*big.Rat, bool) {
+	m := quantityRe.FindStringSubmatch(s)
+	if m == nil {
+		return nil, false
+	}
+	q, ok := new(big.Rat).SetString(m[1])
+	if !ok {
+		return nil, false
+	}
+	return q.Mul(q, quantitySuffixes[m[2]]), true
+}
+
+// positiveQuantity сообщает, что количество больше нуля. Значения,
+// которые не удаётся разобрать, не отвергаются: их формат проверяют
+// правила политики.
+func positiveQuantity(s string) bool {
+	q, ok := parseQuantity(s)
+	return !ok || q.Sign() > 0
+}
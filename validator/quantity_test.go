@@ -0,0 +1,52 @@
+package validator
+
+import "testing"
+
+func TestCheckCPU(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"250m", true},
+		{"0.5", true},
+		{"2", true},
+		{"1000m", true},
+		{"abc", false},
+		{"", false},
+		{"1.5m", false},
+		{".5", false},
+		{"-1", false},
+		{"2 cores", false},
+	}
+	for _, tt := range tests {
+		if got := defaultRules.checkCPU(tt.value); got != tt.want {
+			t.Errorf("checkCPU(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCheckMem(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"128Mi", true},
+		{"1Gi", true},
+		{"512", true},
+		{"1k", true},
+		{"2G", true},
+		{"1Ei", true},
+		{"128MB", false},
+		{"1gi", false},
+		{"1K", false},
+		{"Mi", false},
+		{"-1Mi", false},
+		{"1.5Gi", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := defaultRules.checkMem(tt.value); got != tt.want {
+			t.Errorf("checkMem(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
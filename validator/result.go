@@ -0,0 +1,52 @@
+package validator
+
+import "strings"
+
+// ---------- Result ----------
+
+// Result — находки проверки, разделённые по серьёзности.
+type Result struct {
+	Errors   []Finding
+	Warnings []Finding
+}
+
+// NewResult раскладывает findings на ошибки и предупреждения, сохраняя
+// их порядок.
+func NewResult(findings []Finding) Result {
+	var r Result
+	for _, f := range findings {
+		if f.IsWarning() {
+			r.Warnings = append(r.Warnings, f)
+		} else {
+			r.Errors = append(r.Errors, f)
+		}
+	}
+	return r
+}
+
+// HasErrors сообщает, что манифест не прошёл проверку.
+func (r Result) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// String возвращает по одной находке на строку: сначала ошибки, затем
+// предупреждения.
+func (r Result) String() string {
+	var b strings.Builder
+	for _, list := range [][]Finding{r.Errors, r.Warnings} {
+		for _, f := range list {
+			b.WriteString(f.String())
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// ValidateResult проверяет манифест как Validate и возвращает Result.
+func (v *Validator) ValidateResult(filename string, content []byte) (Result, error) {
+	findings, err := v.Validate(filename, content)
+	if err != nil {
+		return Result{}, err
+	}
+	return NewResult(findings), nil
+}
@@ -0,0 +1,220 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- Rules ----------
+
+// Rules — настраиваемая политика проверки. Значения по умолчанию
+// (DefaultRules) соответствуют встроенным правилам.
+type Rules struct {
+	// Registries — допустимые префиксы образов контейнеров.
+	Registries []string `yaml:"registries"`
+	// ContainerName — регулярное выражение для имён контейнеров
+	// (по умолчанию DNS-1123 label).
+	ContainerName string `yaml:"containerName"`
+	// OS — допустимые значения spec.os.
+	OS []string `yaml:"os"`
+	// CPU и Memory — регулярные выражения для количеств ресурсов.
+	CPU    string `yaml:"cpu"`
+	Memory string `yaml:"memory"`
+	// Severities переопределяет серьёзность находок по идентификатору
+	// правила: "error" или "warning".
+	Severities map[string]string `yaml:"severities"`
+	// DisabledRules — идентификаторы правил, находки которых отбрасываются.
+	DisabledRules []string `yaml:"disabledRules"`
+
+	containerNameRe *regexp.Regexp
+	cpuRe           *regexp.Regexp
+	memoryRe        *regexp.Regexp
+
+	// prepare компилирует политику, собранную вручную, один раз —
+	// Validator может использоваться из нескольких горутин.
+	prepare    sync.Once
+	prepareErr error
+}
+
+// DefaultRules возвращает встроенную политику.
+func DefaultRules() Rules {
+	return Rules{
+		Registries:    []string{DefaultRegistry},
+		ContainerName: dns1123LabelPattern,
+		OS:            []string{"linux", "windows"},
+		// Ядра (целые или дробные) либо милликоры: 2, 0.5, 250m
+		CPU: `^(\d+(\.\d+)?|\d+m)$`,
+		// Число байт, десятичные (k, M, G, T, P, E) и двоичные (Ki, Mi, Gi,
+		// Ti, Pi, Ei) суффиксы
+		Memory: `^\d+([kMGTPE]|[KMGTPE]i)?$`,
+	}
+}
+
+// LoadRules читает политику из YAML-файла. Не указанные в файле поля
+// сохраняют значения по умолчанию, неизвестные поля считаются ошибкой.
+func LoadRules(path string) (*Rules, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRules(content)
+}
+
+// ParseRules разбирает политику из YAML.
+func ParseRules(content []byte) (*Rules, error) {
+	rules := DefaultRules()
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	// Пустой файл оставляет политику по умолчанию
+	if err := dec.Decode(&rules); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := rules.Compile(); err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+// Compile проверяет идентификаторы отключённых правил и компилирует
+// регулярные выражения политики.
+// Пустые шаблоны и списки заменяются значениями DefaultRules: пустое
+// выражение совпадает с любой строкой и молча отключило бы проверку, а
+// пустой список registries или os отверг бы любой образ и любую ОС. Нужно
+// вызвать после ручного изменения полей, но не одновременно с проверкой.
+func (r *Rules) Compile() error {
+	defaults := DefaultRules()
+	if len(r.Registries) == 0 {
+		r.Registries = defaults.Registries
+	}
+	if len(r.OS) == 0 {
+		r.OS = defaults.OS
+	}
+	if r.ContainerName == "" {
+		r.ContainerName = defaults.ContainerName
+	}
+	if r.CPU == "" {
+		r.CPU = defaults.CPU
+	}
+	if r.Memory == "" {
+		r.Memory = defaults.Memory
+	}
+
+	var err error
+	if r.containerNameRe, err = compileRule("containerName", r.ContainerName); err != nil {
+		return err
+	}
+	if r.cpuRe, err = compileRule("cpu", r.CPU); err != nil {
+		return err
+	}
+	if r.memoryRe, err = compileRule("memory", r.Memory); err != nil {
+		return err
+	}
+	for _, rule := range r.DisabledRules {
+		if !knownRule(rule) {
+			return fmt.Errorf("rules: unknown rule '%s' in disabledRules", rule)
+		}
+	}
+	for rule, severity := range r.Severities {
+		if severity != SeverityError && severity != SeverityWarning {
+			return fmt.Errorf("rules: invalid severity '%s' for rule '%s' (must be error or warning)", severity, rule)
+		}
+	}
+	return nil
+}
+
+func compileRule(name, expr string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("rules: invalid %s pattern: %w", name, err)
+	}
+	return re, nil
+}
+
+var defaultRules = func() *Rules {
+	r := DefaultRules()
+	if err := r.Compile(); err != nil {
+		panic(err)
+	}
+	return &r
+}()
+
+// rules возвращает политику валидатора или встроенную, если она не задана.
+func (v *Validator) rules() *Rules {
+	if v.Rules == nil {
+		return defaultRules
+	}
+	return v.Rules
+}
+
+// prepareRules компилирует политику, заданную вручную без Compile.
+func (v *Validator) prepareRules() error {
+	r := v.Rules
+	if r == nil {
+		return nil
+	}
+	r.prepare.Do(func() {
+		if r.containerNameRe == nil {
+			r.prepareErr = r.Compile()
+		}
+	})
+	return r.prepareErr
+}
+
+func (r *Rules) checkCPU(v string) bool {
+	return r.cpuRe.MatchString(v)
+}
+
+// checkMem проверяет формат количества памяти; используется и для
+// размеров томов.
+func (r *Rules) checkMem(v string) bool {
+	return r.memoryRe.MatchString(v)
+}
+
+func (r *Rules) allowedOS(v string) bool {
+	for _, name := range r.OS {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleDisabled сообщает, что находки правила rule отключены политикой.
+func (r *Rules) RuleDisabled(rule string) bool {
+	for _, id := range r.DisabledRules {
+		if id == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// dropDisabled отбрасывает находки отключённых правил.
+func (r *Rules) dropDisabled(findings []Finding) []Finding {
+	if len(r.DisabledRules) == 0 {
+		return findings
+	}
+	kept := findings[:0]
+	for _, f := range findings {
+		if !r.RuleDisabled(f.Rule) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// applySeverities меняет серьёзность находок согласно Severities.
+func (r *Rules) applySeverities(findings []Finding) {
+	for i := range findings {
+		if severity, ok := r.Severities[findings[i].Rule]; ok {
+			findings[i].Severity = severity
+		}
+	}
+}
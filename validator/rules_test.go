@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestHandBuiltRules(t *testing.T) {
+	// Нулевая политика ведёт себя как встроенная
+	v := &Validator{Rules: &Rules{}}
+	valid := strings.Replace(podManifest, "spec:\n", "spec:\n  os: {name: linux}\n", 1)
+	findings, err := v.Validate("pod.yaml", []byte(valid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("zero Rules: unexpected findings %q", messages(findings))
+	}
+
+	manifest := strings.Replace(podManifest, "cpu: 100m", "cpu: abc", 1)
+	manifest = strings.Replace(manifest, "name: app", "name: Bad_Name", 1)
+
+	findings, err = v.Validate("pod.yaml", []byte(manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := map[string]bool{}
+	for _, f := range findings {
+		rules[f.Rule] = true
+	}
+	for _, rule := range []string{ruleCPU, ruleContainerName} {
+		if !rules[rule] {
+			t.Errorf("no %s finding with empty patterns (findings: %q)", rule, messages(findings))
+		}
+	}
+}
+
+func TestEmptyRuleLists(t *testing.T) {
+	rules, err := ParseRules([]byte("registries: []\nos: []\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rules.Registries, []string{DefaultRegistry}) || !reflect.DeepEqual(rules.OS, DefaultRules().OS) {
+		t.Errorf("empty lists not defaulted: registries %q, os %q", rules.Registries, rules.OS)
+	}
+}
+
+func TestDisabledRulesUnknown(t *testing.T) {
+	if _, err := ParseRules([]byte("disabledRules: [image-latest]\n")); err != nil {
+		t.Errorf("known rule: %v", err)
+	}
+	_, err := ParseRules([]byte("disabledRules: [image-lates]\n"))
+	if err == nil || !strings.Contains(err.Error(), "image-lates") {
+		t.Errorf("misspelled rule: got %v, want an error naming it", err)
+	}
+}
+
+// TestRulesConcurrent проверяет вместе с -race, что ручная политика
+// компилируется без гонок.
+func TestRulesConcurrent(t *testing.T) {
+	v := &Validator{Rules: &Rules{}}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := v.Validate("pod.yaml", []byte(podManifest)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
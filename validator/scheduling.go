@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- Tolerations ----------
+
+var (
+	tolerationOperators = map[string]bool{"Exists": true, "Equal": true}
+	tolerationEffects   = map[string]bool{"": true, "NoSchedule": true, "PreferNoSchedule": true, "NoExecute": true}
+)
+
+func (v *Validator) traverseTolerations(filename string, tolerations *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	if tolerations.Kind != yaml.SequenceNode {
+		errorsFound = append(errorsFound, newFinding(filename, tolerations, ruleType, "%s.tolerations must be a list", p.spec))
+		return errorsFound
+	}
+
+	for _, t := range tolerations.Content {
+		if t.Kind != yaml.MappingNode {
+			errorsFound = append(errorsFound, newFinding(filename, t, ruleType, "%s.tolerations entries must be mappings", p.spec))
+			continue
+		}
+		m := nodeMap(t)
+
+		// operator (необязательный, по умолчанию Equal)
+		operator := "Equal"
+		if n, ok := m["operator"]; ok {
+			operator = n.Value
+			if !tolerationOperators[n.Value] {
+				errorsFound = append(errorsFound, newFinding(filename, n, ruleToleration, "%s.tolerations.operator has unsupported value '%s'", p.spec, n.Value))
+			}
+		}
+
+		// value — только для Equal
+		if n, ok := m["value"]; ok && n.Value != "" && operator == "Exists" {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleToleration, "%s.tolerations.value must be empty when operator is Exists", p.spec))
+		}
+
+		// effect
+		effect := ""
+		if n, ok := m["effect"]; ok {
+			effect = n.Value
+			if !tolerationEffects[n.Value] {
+				errorsFound = append(errorsFound, newFinding(filename, n, ruleToleration, "%s.tolerations.effect has unsupported value '%s'", p.spec, n.Value))
+			}
+		}
+
+		// tolerationSeconds — только для NoExecute
+		if n, ok := m["tolerationSeconds"]; ok {
+			if _, err := strconv.ParseInt(n.Value, 10, 64); err != nil {
+				errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.tolerations.tolerationSeconds must be int", p.spec))
+			} else if effect != "NoExecute" {
+				errorsFound = append(errorsFound, newFinding(filename, n, ruleToleration, "%s.tolerations.tolerationSeconds requires effect NoExecute", p.spec))
+			}
+		}
+	}
+
+	return errorsFound
+}
+
+// ---------- Affinity ----------
+
+const (
+	requiredTerms  = "requiredDuringSchedulingIgnoredDuringExecution"
+	preferredTerms = "preferredDuringSchedulingIgnoredDuringExecution"
+)
+
+// traverseAffinity проверяет только структуру spec.affinity: типы узлов,
+// наличие обязательных полей и weight предпочтительных правил.
+func (v *Validator) traverseAffinity(filename string, affinity *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	if affinity.Kind != yaml.MappingNode {
+		errorsFound = append(errorsFound, newFinding(filename, affinity, ruleType, "%s.affinity must be a mapping", p.spec))
+		return errorsFound
+	}
+	m := nodeMap(affinity)
+
+	// nodeAffinity
+	if na, ok := m["nodeAffinity"]; ok {
+		if na.Kind != yaml.MappingNode {
+			errorsFound = append(errorsFound, newFinding(filename, na, ruleType, "%s.affinity.nodeAffinity must be a mapping", p.spec))
+		} else {
+			nm := nodeMap(na)
+			path := p.spec + ".affinity.nodeAffinity"
+			if req, ok := nm[requiredTerms]; ok {
+				terms, ok := nodeMap(req)["nodeSelectorTerms"]
+				if req.Kind != yaml.MappingNode || !ok || terms.Kind != yaml.SequenceNode || len(terms.Content) == 0 {
+					errorsFound = append(errorsFound, newFinding(filename, req, ruleAffinity, "%s.%s must contain a non-empty nodeSelectorTerms list", path, requiredTerms))
+				} else {
+					for _, term := range terms.Content {
+						errorsFound = append(errorsFound, checkSelectorTerm(filename, term, path+"."+requiredTerms+".nodeSelectorTerms")...)
+					}
+				}
+			}
+			if pref, ok := nm[preferredTerms]; ok {
+				errorsFound = append(errorsFound, v.traversePreferredTerms(filename, pref, path, "preference")...)
+			}
+		}
+	}
+
+	// podAffinity, podAntiAffinity
+	for _, kind := range []string{"podAffinity", "podAntiAffinity"} {
+		pa, ok := m[kind]
+		if !ok {
+			continue
+		}
+		path := p.spec + ".affinity." + kind
+		if pa.Kind != yaml.MappingNode {
+			errorsFound = append(errorsFound, newFinding(filename, pa, ruleType, "%s must be a mapping", path))
+			continue
+		}
+		pm := nodeMap(pa)
+		if req, ok := pm[requiredTerms]; ok {
+			if req.Kind != yaml.SequenceNode {
+				errorsFound = append(errorsFound, newFinding(filename, req, ruleType, "%s.%s must be a list", path, requiredTerms))
+			} else {
+				for _, term := range req.Content {
+					errorsFound = append(errorsFound, checkPodAffinityTerm(filename, term, path+"."+requiredTerms)...)
+				}
+			}
+		}
+		if pref, ok := pm[preferredTerms]; ok {
+			errorsFound = append(errorsFound, v.traversePreferredTerms(filename, pref, path, "podAffinityTerm")...)
+		}
+	}
+
+	return errorsFound
+}
+
+// traversePreferredTerms проверяет список предпочтительных правил: weight
+// от 1 до 100 и вложенный term (preference или podAffinityTerm).
+func (v *Validator) traversePreferredTerms(filename string, pref *yaml.Node, path, termKey string) []Finding {
+	var errorsFound []Finding
+	path += "." + preferredTerms
+	if pref.Kind != yaml.SequenceNode {
+		errorsFound = append(errorsFound, newFinding(filename, pref, ruleType, "%s must be a list", path))
+		return errorsFound
+	}
+
+	for _, item := range pref.Content {
+		m := nodeMap(item)
+		if n, ok := m["weight"]; !ok {
+			errorsFound = append(errorsFound, newFinding(filename, item, ruleRequired, "%s.weight is required", path))
+		} else if weight, err := strconv.Atoi(n.Value); err != nil || weight < 1 || weight > 100 {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleAffinity, "%s.weight must be an int between 1 and 100", path))
+		}
+
+		term, ok := m[termKey]
+		switch {
+		case !ok:
+			errorsFound = append(errorsFound, newFinding(filename, item, ruleRequired, "%s.%s is required", path, termKey))
+		case termKey == "preference":
+			errorsFound = append(errorsFound, checkSelectorTerm(filename, term, path+".preference")...)
+		default:
+			errorsFound = append(errorsFound, checkPodAffinityTerm(filename, term, path+"."+termKey)...)
+		}
+	}
+	return errorsFound
+}
+
+// checkSelectorTerm проверяет term узлов: mapping со списками
+// matchExpressions и matchFields.
+func checkSelectorTerm(filename string, term *yaml.Node, path string) []Finding {
+	var errorsFound []Finding
+	if term.Kind != yaml.MappingNode {
+		errorsFound = append(errorsFound, newFinding(filename, term, ruleType, "%s must be a mapping", path))
+		return errorsFound
+	}
+	m := nodeMap(term)
+	for _, field := range []string{"matchExpressions", "matchFields"} {
+		if n, ok := m[field]; ok && n.Kind != yaml.SequenceNode {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.%s must be a list", path, field))
+		}
+	}
+	return errorsFound
+}
+
+// checkPodAffinityTerm проверяет term подов: mapping с обязательным
+// topologyKey.
+func checkPodAffinityTerm(filename string, term *yaml.Node, path string) []Finding {
+	var errorsFound []Finding
+	if term.Kind != yaml.MappingNode {
+		errorsFound = append(errorsFound, newFinding(filename, term, ruleType, "%s must be a mapping", path))
+		return errorsFound
+	}
+	if n, ok := nodeMap(term)["topologyKey"]; !ok || n.Value == "" {
+		errorsFound = append(errorsFound, newFinding(filename, term, ruleRequired, "%s.topologyKey is required", path))
+	}
+	return errorsFound
+}
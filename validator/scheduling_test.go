@@ -0,0 +1,15 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTolerationEntries(t *testing.T) {
+	manifest := strings.Replace(podManifest, "spec:\n", "spec:\n  tolerations: [a, {operator: Exists}]\n", 1)
+	want := []string{"spec.tolerations entries must be mappings"}
+	if got := messages(validate(t, manifest)); !reflect.DeepEqual(got, want) {
+		t.Errorf("messages = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- JSON Schema ----------
+
+// Schema — внешняя JSON Schema (Draft 7), которой дополнительно должен
+// соответствовать каждый документ.
+type Schema struct {
+	schema *jsonschema.Schema
+}
+
+// LoadSchema компилирует JSON Schema из файла.
+func LoadSchema(path string) (*Schema, error) {
+	c := jsonschema.NewCompiler()
+	c.Draft = jsonschema.Draft7
+	s, err := c.Compile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: s}, nil
+}
+
+func (v *Validator) traverseSchema(filename string, doc *yaml.Node) []Finding {
+	var errorsFound []Finding
+
+	err := v.Schema.schema.Validate(nodeValue(doc))
+	var ve *jsonschema.ValidationError
+	if !errors.As(err, &ve) {
+		return errorsFound
+	}
+
+	for _, leaf := range schemaLeaves(ve) {
+		n := nodeAtPointer(doc, leaf.InstanceLocation)
+		path := leaf.InstanceLocation
+		if path == "" {
+			path = "/"
+		}
+		errorsFound = append(errorsFound, newFinding(filename, n, ruleJSONSchema, "schema: %s %s", path, leaf.Message))
+	}
+	return errorsFound
+}
+
+// schemaLeaves возвращает конечные причины ошибки: именно они указывают
+// на конкретное нарушение.
+func schemaLeaves(ve *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(ve.Causes) == 0 {
+		return []*jsonschema.ValidationError{ve}
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, c := range ve.Causes {
+		leaves = append(leaves, schemaLeaves(c)...)
+	}
+	return leaves
+}
+
+// nodeValue превращает узел YAML в значение, понятное валидатору схем:
+// map[string]interface{}, []interface{} и скаляры.
+func nodeValue(n *yaml.Node) interface{} {
+	switch n.Kind {
+	case yaml.AliasNode:
+		return nodeValue(n.Alias)
+	case yaml.MappingNode:
+		obj := make(map[string]interface{}, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			obj[n.Content[i].Value] = nodeValue(n.Content[i+1])
+		}
+		return obj
+	case yaml.SequenceNode:
+		arr := make([]interface{}, 0, len(n.Content))
+		for _, item := range n.Content {
+			arr = append(arr, nodeValue(item))
+		}
+		return arr
+	default:
+		var val interface{}
+		if err := n.Decode(&val); err != nil {
+			return n.Value
+		}
+		return val
+	}
+}
+
+// nodeAtPointer находит узел по JSON Pointer вида /spec/containers/0.
+// Если путь не найден целиком, возвращается ближайший найденный предок.
+func nodeAtPointer(doc *yaml.Node, pointer string) *yaml.Node {
+	n := doc
+	if pointer == "" {
+		return n
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		next := childNode(n, token)
+		if next == nil {
+			return n
+		}
+		n = next
+	}
+	return n
+}
+
+func childNode(n *yaml.Node, token string) *yaml.Node {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return nodeMap(n)[token]
+	case yaml.SequenceNode:
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 || i >= len(n.Content) {
+			return nil
+		}
+		return n.Content[i]
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- SecurityContext ----------
+
+var (
+	securityBoolFields = []string{"runAsNonRoot", "readOnlyRootFilesystem", "privileged", "allowPrivilegeEscalation"}
+	securityIDFields   = []string{"runAsUser", "runAsGroup"}
+)
+
+func (v *Validator) traverseSecurityContext(filename string, sc *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	if isNull(sc) {
+		errorsFound = append(errorsFound, newFinding(filename, sc, ruleNull, "%s.securityContext must not be null", p.container))
+		return errorsFound
+	}
+	if sc.Kind != yaml.MappingNode {
+		errorsFound = append(errorsFound, newFinding(filename, sc, ruleType, "%s.securityContext must be a mapping", p.container))
+		return errorsFound
+	}
+	m := nodeMap(sc)
+
+	for _, field := range securityBoolFields {
+		if n, ok := m[field]; ok && !isBool(n) {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.securityContext.%s must be bool", p.container, field))
+		}
+	}
+
+	for _, field := range securityIDFields {
+		n, ok := m[field]
+		if !ok {
+			continue
+		}
+		if id, err := strconv.Atoi(n.Value); n.Kind != yaml.ScalarNode || err != nil {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleType, "%s.securityContext.%s must be int", p.container, field))
+		} else if id < 0 {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleSecurityContext, "%s.securityContext.%s must be non-negative", p.container, field))
+		}
+	}
+
+	// Привилегированный контейнер формально допустим, но в Strict о нём сообщаем
+	if n, ok := m["privileged"]; ok && v.Strict && n.Value == "true" {
+		errorsFound = append(errorsFound, newWarning(filename, n, rulePrivileged, "%s.securityContext.privileged should not be true", p.container))
+	}
+
+	return errorsFound
+}
+
+// traverseRunAsNonRoot в Strict предупреждает о контейнерах, для которых
+// runAsNonRoot не задан ни в контейнере, ни в securityContext пода.
+func (v *Validator) traverseRunAsNonRoot(filename string, spec *yaml.Node, containers []*yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	if !v.Strict {
+		return errorsFound
+	}
+	if sc, ok := nodeMap(spec)["securityContext"]; ok {
+		if _, ok := nodeMap(sc)["runAsNonRoot"]; ok {
+			return errorsFound
+		}
+	}
+
+	for _, c := range containers {
+		sc, ok := nodeMap(c)["securityContext"]
+		if ok {
+			if _, ok := nodeMap(sc)["runAsNonRoot"]; ok {
+				continue
+			}
+		}
+		errorsFound = append(errorsFound, newWarning(filename, c, ruleRunAsNonRoot, "%s.securityContext.runAsNonRoot should be set", p.container))
+	}
+	return errorsFound
+}
@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- Strict: неизвестные поля ----------
+
+func keySet(keys ...string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		set[k] = true
+	}
+	return set
+}
+
+// knownKeys — поля, которые Kubernetes допускает на каждом уровне
+// манифеста. Используется только в режиме Strict.
+var knownKeys = map[string]map[string]bool{
+	"object": keySet("apiVersion", "kind", "metadata", "spec", "status"),
+	"metadata": keySet(
+		"name", "generateName", "namespace", "labels", "annotations", "uid",
+		"resourceVersion", "generation", "creationTimestamp", "deletionTimestamp",
+		"deletionGracePeriodSeconds", "ownerReferences", "finalizers", "managedFields",
+	),
+	"spec": keySet(
+		"activeDeadlineSeconds", "affinity", "automountServiceAccountToken", "containers",
+		"dnsConfig", "dnsPolicy", "enableServiceLinks", "ephemeralContainers", "hostAliases",
+		"hostIPC", "hostNetwork", "hostPID", "hostUsers", "hostname", "imagePullSecrets",
+		"initContainers", "nodeName", "nodeSelector", "os", "overhead", "preemptionPolicy",
+		"priority", "priorityClassName", "readinessGates", "resourceClaims", "restartPolicy",
+		"runtimeClassName", "schedulerName", "schedulingGates", "securityContext",
+		"serviceAccount", "serviceAccountName", "setHostnameAsFQDN", "shareProcessNamespace",
+		"subdomain", "terminationGracePeriodSeconds", "tolerations",
+		"topologySpreadConstraints", "volumes",
+	),
+	"container": keySet(
+		"args", "command", "env", "envFrom", "image", "imagePullPolicy", "lifecycle",
+		"livenessProbe", "name", "ports", "readinessProbe", "resizePolicy", "resources",
+		"restartPolicy", "securityContext", "startupProbe", "stdin", "stdinOnce",
+		"terminationMessagePath", "terminationMessagePolicy", "tty", "volumeDevices",
+		"volumeMounts", "workingDir",
+	),
+	"port":      keySet("containerPort", "hostIP", "hostPort", "name", "protocol"),
+	"probe":     keySet("exec", "failureThreshold", "grpc", "httpGet", "initialDelaySeconds", "periodSeconds", "successThreshold", "tcpSocket", "terminationGracePeriodSeconds", "timeoutSeconds"),
+	"httpGet":   keySet("host", "httpHeaders", "path", "port", "scheme"),
+	"resources": keySet("claims", "limits", "requests"),
+}
+
+// traverseUnknownKeys сообщает о полях node, которых нет в knownKeys[level].
+// path — префикс поля в сообщении, например "spec.".
+func (v *Validator) traverseUnknownKeys(filename string, node *yaml.Node, level, path string) []Finding {
+	var errorsFound []Finding
+	if !v.Strict || node.Kind != yaml.MappingNode {
+		return errorsFound
+	}
+
+	known := knownKeys[level]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode := node.Content[i]
+		if !known[keyNode.Value] {
+			errorsFound = append(errorsFound, newFinding(filename, keyNode, ruleUnknownField, "unknown field '%s%s'", path, keyNode.Value))
+		}
+	}
+	return errorsFound
+}
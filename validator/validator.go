@@ -0,0 +1,373 @@
+// Package validator проверяет YAML-манифесты Kubernetes на соответствие
+// правилам yamlvalid.
+package validator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Validator хранит настройки проверки. Нулевое значение готово
+// к использованию и соответствует поведению по умолчанию.
+type Validator struct {
+	// Strict включает проверку неизвестных полей и рекомендации
+	// strictAdvisories и превращает остальные предупреждения в ошибки.
+	Strict bool
+	// Rules — настраиваемая политика; nil означает DefaultRules.
+	Rules *Rules
+	// DisallowLatest запрещает тег latest у образов.
+	DisallowLatest bool
+	// Schema — дополнительная JSON Schema для каждого документа.
+	Schema *Schema
+	// SchemaOnly отключает встроенные правила, оставляя только Schema.
+	SchemaOnly bool
+	// RequireLimits требует requests и limits для cpu и memory
+	// в каждом контейнере.
+	RequireLimits bool
+	// WarnMissingProbes предупреждает о контейнерах без readinessProbe
+	// и livenessProbe.
+	WarnMissingProbes bool
+	// Kinds ограничивает допустимые kind документов; nil — все
+	// поддерживаемые (SupportedKinds).
+	Kinds []string
+}
+
+// DefaultRegistry — единственный реестр образов, допустимый по умолчанию.
+const DefaultRegistry = "registry.bigbrother.io/"
+
+// Validate проверяет манифест с настройками по умолчанию.
+func Validate(filename string, content []byte) ([]Finding, error) {
+	return (&Validator{}).Validate(filename, content)
+}
+
+// ValidateReader проверяет манифест из r с настройками по умолчанию.
+func ValidateReader(filename string, r io.Reader) ([]Finding, error) {
+	return (&Validator{}).ValidateReader(filename, r)
+}
+
+// ValidateReader читает манифест из r и проверяет его как Validate.
+// Парсеру YAML нужен весь документ, поэтому r читается целиком.
+func (v *Validator) ValidateReader(filename string, r io.Reader) ([]Finding, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return v.Validate(filename, content)
+}
+
+// Validate разбирает content и проверяет каждый документ манифеста.
+// filename используется только в находках. Ошибка возвращается, если
+// content не является YAML.
+func (v *Validator) Validate(filename string, content []byte) ([]Finding, error) {
+	return v.ValidateContext(context.Background(), filename, content)
+}
+
+// ValidateContext проверяет манифест как Validate, но между документами
+// проверяет ctx и при отмене возвращает ctx.Err().
+func (v *Validator) ValidateContext(ctx context.Context, filename string, content []byte) ([]Finding, error) {
+	if err := v.prepareRules(); err != nil {
+		return nil, err
+	}
+	docs, err := decodeDocuments(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return []Finding{{File: filename, Rule: ruleEmptyDocument, Severity: SeverityError, Message: "empty YAML document"}}, nil
+	}
+
+	var findings []Finding
+	for _, doc := range docs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var docFindings []Finding
+		if !v.SchemaOnly {
+			docFindings = append(docFindings, v.traversePod(filename, doc)...)
+			docFindings = append(docFindings, v.traverseDuplicateKeys(filename, doc)...)
+		}
+		if v.Schema != nil {
+			docFindings = append(docFindings, v.traverseSchema(filename, doc)...)
+		}
+
+		ignores := ignoreSet{}
+		collectIgnores(doc, ignores)
+		findings = append(findings, ignores.filter(docFindings)...)
+	}
+
+	findings = v.rules().dropDisabled(findings)
+	v.rules().applySeverities(findings)
+	if v.Strict {
+		for i := range findings {
+			if !strictAdvisories[findings[i].Rule] {
+				findings[i].Severity = SeverityError
+			}
+		}
+	}
+	return findings, nil
+}
+
+// decodeDocuments возвращает корневые узлы всех непустых документов потока.
+// Пустые документы (например, после завершающего "---") пропускаются.
+func decodeDocuments(content []byte) ([]*yaml.Node, error) {
+	var docs []*yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var root yaml.Node
+		err := dec.Decode(&root)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(root.Content) == 0 || isNull(root.Content[0]) {
+			continue
+		}
+		docs = append(docs, root.Content[0])
+	}
+}
+
+// ---------- Findings ----------
+
+// Finding — одно нарушение правил валидации.
+type Finding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// Уровни серьёзности находок. Предупреждения не влияют на код
+// завершения, если только Strict не превращает их в ошибки.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// IsWarning сообщает, что находка носит рекомендательный характер.
+func (f Finding) IsWarning() bool {
+	return f.Severity == SeverityWarning
+}
+
+// Стабильные идентификаторы правил.
+const (
+	ruleEmptyDocument     = "empty-document"
+	ruleRequired          = "required"
+	ruleType              = "invalid-type"
+	ruleNull              = "null-value"
+	ruleAPIVersion        = "api-version"
+	ruleKind              = "kind"
+	ruleCronSchedule      = "cron-schedule"
+	ruleConcurrencyPolicy = "concurrency-policy"
+	ruleRestartPolicy     = "restart-policy"
+	ruleDNSPolicy         = "dns-policy"
+	rulePriority          = "priority"
+	ruleReplicas          = "replicas"
+	ruleLabel             = "label-format"
+	ruleAnnotation        = "annotation-key"
+	ruleObjectName        = "object-name"
+	ruleNamespace         = "namespace"
+	ruleServiceAccount    = "service-account-name"
+	ruleHostAlias         = "host-alias"
+	ruleDuplicateKey      = "duplicate-key"
+	ruleUnknownField      = "unknown-field"
+	ruleJSONSchema        = "json-schema"
+	ruleEnvName           = "env-name"
+	ruleEnvSource         = "env-source"
+	ruleVolumeMount       = "volume-mount"
+	ruleVolume            = "volume"
+	ruleOS                = "os"
+	ruleToleration        = "toleration"
+	ruleAffinity          = "affinity"
+	ruleContainerName     = "container-name"
+	ruleDuplicateName     = "duplicate-container-name"
+	ruleImageRegistry     = "image-registry-prefix"
+	ruleImageTag          = "image-tag"
+	ruleImageLatest       = "image-latest"
+	ruleImageDigest       = "image-digest"
+	ruleImagePullPolicy   = "image-pull-policy"
+	ruleWorkingDir        = "working-dir"
+	rulePortRange         = "port-range"
+	ruleHostPortPrivilege = "privileged-host-port"
+	ruleDuplicatePort     = "duplicate-port"
+	rulePortName          = "port-name"
+	ruleProtocol          = "protocol"
+	ruleProbePath         = "probe-path"
+	ruleProbeHandler      = "probe-handler"
+	ruleProbeScheme       = "probe-scheme"
+	ruleProbeHost         = "probe-host"
+	ruleProbePort         = "probe-port"
+	ruleProbeTiming       = "probe-timing"
+	ruleInitProbe         = "init-container-probe"
+	ruleMissingProbe      = "missing-probe"
+	ruleIdenticalProbes   = "identical-probes"
+	ruleSecurityContext   = "security-context"
+	rulePrivileged        = "privileged-container"
+	ruleRunAsNonRoot      = "run-as-non-root"
+	ruleCPU               = "cpu-format"
+	ruleMemory            = "memory-format"
+	ruleExtendedResource  = "extended-resource"
+	ruleUnknownResource   = "unknown-resource"
+	ruleRequestsLimits    = "requests-exceed-limits"
+)
+
+// strictAdvisories — правила, которые сообщают только в Strict и только
+// предупреждения: Strict не превращает их в ошибки.
+var strictAdvisories = map[string]bool{
+	ruleHostPortPrivilege: true,
+	rulePrivileged:        true,
+	ruleRunAsNonRoot:      true,
+	ruleUnknownResource:   true,
+}
+
+func newFinding(filename string, n *yaml.Node, rule, format string, args ...any) Finding {
+	return Finding{
+		File:     filename,
+		Line:     n.Line,
+		Column:   n.Column,
+		Rule:     rule,
+		Severity: SeverityError,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+func newWarning(filename string, n *yaml.Node, rule, format string, args ...any) Finding {
+	f := newFinding(filename, n, rule, format, args...)
+	f.Severity = SeverityWarning
+	return f
+}
+
+// Location возвращает позицию находки в виде "file:line:col" (без
+// неизвестных частей).
+func (f Finding) Location() string {
+	switch {
+	case f.Line > 0 && f.Column > 0:
+		return fmt.Sprintf("%s:%d:%d", f.File, f.Line, f.Column)
+	case f.Line > 0:
+		return fmt.Sprintf("%s:%d", f.File, f.Line)
+	default:
+		return f.File
+	}
+}
+
+func (f Finding) String() string {
+	msg := f.Message
+	if f.IsWarning() {
+		msg = "warning: " + msg
+	}
+	switch {
+	case f.Line > 0:
+		return f.Location() + " " + msg
+	case f.File != "":
+		return f.Location() + ": " + msg
+	default:
+		return msg
+	}
+}
+
+// ---------- Duplicate keys ----------
+
+// traverseDuplicateKeys обходит всё дерево документа: nodeMap молча
+// оставляет последнее значение повторённого ключа, а Kubernetes такие
+// манифесты отвергает.
+func (v *Validator) traverseDuplicateKeys(filename string, n *yaml.Node) []Finding {
+	var errorsFound []Finding
+
+	if n.Kind == yaml.MappingNode {
+		seen := map[string]bool{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			keyNode := n.Content[i]
+			if seen[keyNode.Value] {
+				errorsFound = append(errorsFound, newFinding(filename, keyNode, ruleDuplicateKey, "duplicate key '%s'", keyNode.Value))
+			}
+			seen[keyNode.Value] = true
+		}
+	}
+
+	for _, child := range n.Content {
+		errorsFound = append(errorsFound, v.traverseDuplicateKeys(filename, child)...)
+	}
+	return errorsFound
+}
+
+// ---------- Утилита ----------
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
+
+// isBool сообщает, что n — логическое значение, а не строка "true".
+func isBool(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!bool"
+}
+
+// presentKeys возвращает те из keys, что заданы в m, в порядке keys.
+// Удобно для полей, из которых допустимо ровно одно.
+func presentKeys(m map[string]*yaml.Node, keys ...string) []string {
+	var present []string
+	for _, k := range keys {
+		if _, ok := m[k]; ok {
+			present = append(present, k)
+		}
+	}
+	return present
+}
+
+// sameNode структурно сравнивает два узла: порядок ключей, стиль
+// записи, комментарии и якоря не учитываются.
+func sameNode(a, b *yaml.Node) bool {
+	a, b = resolveAlias(a), resolveAlias(b)
+	if a.Kind != b.Kind || len(a.Content) != len(b.Content) {
+		return false
+	}
+	switch a.Kind {
+	case yaml.ScalarNode:
+		return a.Tag == b.Tag && a.Value == b.Value
+	case yaml.MappingNode:
+		bm := nodeMap(b)
+		for k, av := range nodeMap(a) {
+			bv, ok := bm[k]
+			if !ok || !sameNode(av, bv) {
+				return false
+			}
+		}
+		return true
+	}
+	for i := range a.Content {
+		if !sameNode(a.Content[i], b.Content[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeMap возвращает поля отображения; ссылки (*anchor) заменяются
+// узлами, на которые они указывают.
+func nodeMap(n *yaml.Node) map[string]*yaml.Node {
+	m := map[string]*yaml.Node{}
+	n = resolveAlias(n)
+	if n.Kind == yaml.MappingNode {
+		for i := 0; i < len(n.Content); i += 2 {
+			keyNode := n.Content[i]
+			valueNode := resolveAlias(n.Content[i+1])
+			m[keyNode.Value] = valueNode
+		}
+	}
+	return m
+}
+
+// resolveAlias возвращает узел, на который ссылается alias, или сам n.
+func resolveAlias(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
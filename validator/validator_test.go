@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// podManifest — корректный Pod; тесты подставляют в него свои поля
+// через strings.Replace.
+const podManifest = `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  containers:
+    - name: app
+      image: registry.bigbrother.io/app:1.0
+      resources:
+        requests: {cpu: 100m, memory: 64Mi}
+`
+
+func validate(t *testing.T, manifest string) []Finding {
+	t.Helper()
+	findings, err := Validate("pod.yaml", []byte(manifest))
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	return findings
+}
+
+// messages возвращает сообщения находок в порядке появления.
+func messages(findings []Finding) []string {
+	msgs := make([]string, len(findings))
+	for i, f := range findings {
+		msgs[i] = f.Message
+	}
+	return msgs
+}
+
+func TestValidateDeterministic(t *testing.T) {
+	manifest := strings.Replace(podManifest, "{cpu: 100m, memory: 64Mi}", "{cpu: abc, memory: 1GB}", 1)
+	want := []string{
+		"requests.cpu has invalid format 'abc'",
+		"requests.memory has invalid format '1GB'",
+	}
+
+	first := validate(t, manifest)
+	if got := messages(first); !reflect.DeepEqual(got, want) {
+		t.Fatalf("messages = %q, want %q", got, want)
+	}
+	// Порядок обхода map в Go случаен, поэтому повторяем несколько раз
+	for i := 0; i < 20; i++ {
+		if got := validate(t, manifest); !reflect.DeepEqual(got, first) {
+			t.Fatalf("run %d: findings = %v, want %v", i, got, first)
+		}
+	}
+}
+
+// BenchmarkValidate проверяет Pod со множеством контейнеров; по числу
+// аллокаций видно, что регулярные выражения не компилируются заново.
+func BenchmarkValidate(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString("apiVersion: v1\nkind: Pod\nmetadata:\n  name: web\nspec:\n  containers:\n")
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(&sb, "    - name: app-%d\n", i)
+		sb.WriteString("      image: registry.bigbrother.io/app:1.0\n")
+		sb.WriteString("      resources:\n        requests: {cpu: 100m, memory: 64Mi}\n        limits: {cpu: 500m, memory: 128Mi}\n")
+	}
+	content := []byte(sb.String())
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := Validate("pod.yaml", content); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
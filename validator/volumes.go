@@ -0,0 +1,156 @@
+package validator
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- Volumes ----------
+
+// volumeSources — источники томов core/v1 Volume.
+var volumeSources = []string{
+	"awsElasticBlockStore", "azureDisk", "azureFile", "cephfs", "cinder", "configMap",
+	"csi", "downwardAPI", "emptyDir", "ephemeral", "fc", "flexVolume", "flocker",
+	"gcePersistentDisk", "gitRepo", "glusterfs", "hostPath", "image", "iscsi", "nfs",
+	"persistentVolumeClaim", "photonPersistentDisk", "portworxVolume", "projected",
+	"quobyte", "rbd", "scaleIO", "secret", "storageos", "vsphereVolume",
+}
+
+// volumeSourceKeys — обязательное имя-поле источника тома.
+var volumeSourceKeys = map[string]string{
+	"configMap": "name",
+	"secret":    "secretName",
+	"hostPath":  "path",
+}
+
+func (v *Validator) traverseVolumes(filename string, volumes *yaml.Node, p podPath) []Finding {
+	var errorsFound []Finding
+	if isNull(volumes) {
+		errorsFound = append(errorsFound, newFinding(filename, volumes, ruleNull, "%s.volumes must not be null", p.spec))
+		return errorsFound
+	}
+	if volumes.Kind != yaml.SequenceNode {
+		errorsFound = append(errorsFound, newFinding(filename, volumes, ruleType, "%s.volumes must be a list", p.spec))
+		return errorsFound
+	}
+
+	seen := map[string]bool{}
+	for _, vol := range volumes.Content {
+		m := nodeMap(vol)
+
+		// name
+		if n, ok := m["name"]; !ok || n.Value == "" {
+			errorsFound = append(errorsFound, newFinding(filename, vol, ruleRequired, "%svolumes.name is required", p.field))
+		} else {
+			if seen[n.Value] {
+				errorsFound = append(errorsFound, newFinding(filename, n, ruleVolume, "%sduplicate volume name '%s'", p.at(p.spec+".volumes"), n.Value))
+			}
+			seen[n.Value] = true
+		}
+
+		// источник тома
+		sources := presentKeys(m, volumeSources...)
+		switch {
+		case len(sources) == 0:
+			errorsFound = append(errorsFound, newFinding(filename, vol, ruleVolume, "%svolume must specify a source such as emptyDir, configMap, secret or persistentVolumeClaim", p.at(p.spec+".volumes")))
+			continue
+		case len(sources) > 1:
+			errorsFound = append(errorsFound, newFinding(filename, m[sources[1]], ruleVolume, "%svolume must specify exactly one source, got %s", p.at(p.spec+".volumes"), strings.Join(sources, ", ")))
+			continue
+		}
+		source := sources[0]
+		sourceNode := m[source]
+		sm := nodeMap(sourceNode)
+
+		if key, ok := volumeSourceKeys[source]; ok {
+			if n, ok := sm[key]; !ok || n.Value == "" {
+				errorsFound = append(errorsFound, newFinding(filename, sourceNode, ruleRequired, "%svolumes.%s.%s is required", p.field, source, key))
+			}
+		}
+
+		// items проецируют отдельные ключи configMap или secret в файлы
+		if itemsNode, ok := sm["items"]; ok && (source == "configMap" || source == "secret") {
+			errorsFound = append(errorsFound, v.traverseVolumeItems(filename, itemsNode, p.field+"volumes."+source)...)
+		}
+
+		if source == "emptyDir" {
+			// medium: "" — диск узла, Memory — tmpfs
+			if n, ok := sm["medium"]; ok && n.Value != "" && n.Value != "Memory" {
+				errorsFound = append(errorsFound, newFinding(filename, n, ruleVolume, "%svolumes.emptyDir.medium has unsupported value '%s'", p.field, n.Value))
+			}
+			if n, ok := sm["sizeLimit"]; ok && !v.rules().checkMem(n.Value) {
+				errorsFound = append(errorsFound, newFinding(filename, n, ruleMemory, "%svolumes.emptyDir.sizeLimit has invalid format '%s'", p.field, n.Value))
+			}
+		}
+	}
+
+	return errorsFound
+}
+
+// traverseVolumeItems проверяет items тома configMap или secret; path —
+// путь к источнику тома.
+func (v *Validator) traverseVolumeItems(filename string, items *yaml.Node, path string) []Finding {
+	var errorsFound []Finding
+	if items.Kind != yaml.SequenceNode {
+		errorsFound = append(errorsFound, newFinding(filename, items, ruleType, "%s.items must be a list", path))
+		return errorsFound
+	}
+
+	for _, item := range items.Content {
+		m := nodeMap(item)
+		if n, ok := m["key"]; !ok || n.Value == "" {
+			errorsFound = append(errorsFound, newFinding(filename, item, ruleRequired, "%s.items.key is required", path))
+		}
+		if n, ok := m["path"]; !ok || n.Value == "" {
+			errorsFound = append(errorsFound, newFinding(filename, item, ruleRequired, "%s.items.path is required", path))
+		} else if strings.HasPrefix(n.Value, "/") {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleVolume, "%s.items.path must be relative", path))
+		}
+	}
+	return errorsFound
+}
+
+// volumeNames собирает имена из spec.volumes, на которые могут
+// ссылаться volumeMounts контейнеров.
+func volumeNames(spec map[string]*yaml.Node) map[string]bool {
+	names := map[string]bool{}
+	if volNode, ok := spec["volumes"]; ok && volNode.Kind == yaml.SequenceNode {
+		for _, vol := range volNode.Content {
+			if n, ok := nodeMap(vol)["name"]; ok && n.Value != "" {
+				names[n.Value] = true
+			}
+		}
+	}
+	return names
+}
+
+func (v *Validator) traverseVolumeMounts(filename string, mounts *yaml.Node, volumes map[string]bool, p podPath) []Finding {
+	var errorsFound []Finding
+	if isNull(mounts) {
+		errorsFound = append(errorsFound, newFinding(filename, mounts, ruleNull, "%s.volumeMounts must not be null", p.container))
+		return errorsFound
+	}
+	if mounts.Kind != yaml.SequenceNode {
+		errorsFound = append(errorsFound, newFinding(filename, mounts, ruleType, "%s.volumeMounts must be a list", p.container))
+		return errorsFound
+	}
+
+	for _, mount := range mounts.Content {
+		m := nodeMap(mount)
+
+		// name
+		if n, ok := m["name"]; !ok || n.Value == "" {
+			errorsFound = append(errorsFound, newFinding(filename, mount, ruleRequired, "%svolumeMounts.name is required", p.inner))
+		} else if !volumes[n.Value] {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleVolumeMount, "%svolumeMount references undefined volume '%s'", p.at(p.container+".volumeMounts"), n.Value))
+		}
+
+		// mountPath
+		if n, ok := m["mountPath"]; !ok || n.Value == "" {
+			errorsFound = append(errorsFound, newFinding(filename, mount, ruleRequired, "%svolumeMounts.mountPath is required", p.inner))
+		}
+	}
+
+	return errorsFound
+}
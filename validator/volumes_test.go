@@ -0,0 +1,49 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestVolumeSources(t *testing.T) {
+	tests := []struct {
+		name   string
+		volume string
+		want   []string
+	}{
+		{
+			name:   "projected",
+			volume: "{name: data, projected: {sources: [{serviceAccountToken: {path: token}}]}}",
+			want:   []string{},
+		},
+		{
+			name:   "csi",
+			volume: "{name: data, csi: {driver: example.com/driver}}",
+			want:   []string{},
+		},
+		{
+			name:   "downwardAPI",
+			volume: "{name: data, downwardAPI: {items: [{path: labels, fieldRef: {fieldPath: metadata.labels}}]}}",
+			want:   []string{},
+		},
+		{
+			name:   "none",
+			volume: "{name: data}",
+			want:   []string{"volume must specify a source such as emptyDir, configMap, secret or persistentVolumeClaim"},
+		},
+		{
+			name:   "two",
+			volume: "{name: data, emptyDir: {}, nfs: {server: nfs.local, path: /data}}",
+			want:   []string{"volume must specify exactly one source, got emptyDir, nfs"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := strings.Replace(podManifest, "spec:\n", "spec:\n  volumes:\n    - "+tt.volume+"\n", 1)
+			if got := messages(validate(t, manifest)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("messages = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ---------- Deployment / StatefulSet ----------
+
+func (v *Validator) traverseWorkload(filename string, doc *yaml.Node) []Finding {
+	errorsFound := v.traverseObject(filename, doc, nodeMap(doc)["kind"].Value)
+	m := nodeMap(doc)
+
+	// spec
+	specNode, ok := m["spec"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, doc, ruleRequired, "spec is required"))
+		return errorsFound
+	}
+	sm := nodeMap(specNode)
+
+	// replicas (необязательное, по умолчанию 1)
+	if n, ok := sm["replicas"]; ok {
+		if replicas, err := strconv.Atoi(n.Value); err != nil || replicas < 0 {
+			errorsFound = append(errorsFound, newFinding(filename, n, ruleReplicas, "spec.replicas must be a non-negative int"))
+		}
+	}
+
+	// selector.matchLabels
+	if selNode, ok := sm["selector"]; !ok {
+		errorsFound = append(errorsFound, newFinding(filename, specNode, ruleRequired, "spec.selector is required"))
+	} else if labels, ok := nodeMap(selNode)["matchLabels"]; !ok || len(labels.Content) == 0 {
+		errorsFound = append(errorsFound, newFinding(filename, selNode, ruleRequired, "spec.selector.matchLabels is required"))
+	}
+
+	// template.spec
+	tmplNode, ok := sm["template"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, specNode, ruleRequired, "spec.template is required"))
+		return errorsFound
+	}
+	podSpec, ok := nodeMap(tmplNode)["spec"]
+	if !ok {
+		errorsFound = append(errorsFound, newFinding(filename, tmplNode, ruleRequired, "spec.template.spec is required"))
+		return errorsFound
+	}
+
+	return append(errorsFound, v.traverseSpec(filename, podSpec, podPaths("spec.template"))...)
+}